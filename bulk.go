@@ -0,0 +1,193 @@
+package gotermii
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// MaxBulkRecipients is the maximum number of recipients termii accepts in a single bulk request
+	MaxBulkRecipients = 10000
+
+	defaultBulkConcurrency = 4
+)
+
+// BulkMessageRequest is a representation of a send bulk message request. To may hold any number
+// of recipients, it is split into batches of at most MaxBulkRecipients.
+type BulkMessageRequest struct {
+	To      []string `json:"to"`
+	From    string   `json:"from"`
+	Sms     string   `json:"sms"`
+	Type    SMSType  `json:"type"`
+	Channel Channel  `json:"channel"`
+	APIKey  string   `json:"api_key"`
+}
+
+// BulkMessageResponse is a representation of a send bulk message response
+type BulkMessageResponse struct {
+	Code      string      `json:"code"`
+	MessageID string      `json:"message_id"`
+	Message   string      `json:"message"`
+	Balance   interface{} `json:"balance"`
+	User      string      `json:"user"`
+}
+
+// BulkBatchResult is a representation of the outcome of sending a single batch of a bulk message
+type BulkBatchResult struct {
+	Index      int
+	Recipients []string
+	Response   BulkMessageResponse
+	Err        error
+}
+
+// BulkMessageResult is a representation of the aggregated outcome of a bulk message
+type BulkMessageResult struct {
+	Batches []BulkBatchResult
+	// Sent is the number of recipients in batches accepted by termii
+	Sent int
+	// Failed is the number of recipients in batches that failed
+	Failed int
+}
+
+// MessageIDs returns the message id of every batch accepted by termii
+func (r BulkMessageResult) MessageIDs() []string {
+	var ids []string
+	for _, b := range r.Batches {
+		if b.Err == nil {
+			ids = append(ids, b.Response.MessageID)
+		}
+	}
+	return ids
+}
+
+// FailedBatches returns the batches that were not accepted by termii, they can be retried by the caller
+func (r BulkMessageResult) FailedBatches() []BulkBatchResult {
+	var failed []BulkBatchResult
+	for _, b := range r.Batches {
+		if b.Err != nil {
+			failed = append(failed, b)
+		}
+	}
+	return failed
+}
+
+// WithBulkBatchSize sets the number of recipients sent per bulk request, capped at MaxBulkRecipients
+func WithBulkBatchSize(size int) Option {
+	return func(c *Client) {
+		c.bulkBatchSize = size
+	}
+}
+
+// WithBulkConcurrency sets the number of bulk requests sent concurrently, defaults to 4
+func WithBulkConcurrency(n int) Option {
+	return func(c *Client) {
+		c.bulkConcurrency = n
+	}
+}
+
+// SendBulkMessage allows businesses send a message to several recipients.
+// See docs https://developers.termii.com/messaging#send-bulk-message for more details
+func (c Client) SendBulkMessage(req BulkMessageRequest) (BulkMessageResult, error) {
+	return c.SendBulkMessageWithContext(context.Background(), req)
+}
+
+// SendBulkMessageWithContext is like SendBulkMessage but carries ctx through to the underlying http requests.
+// Recipients are split into batches sent concurrently, the result reports the outcome of every batch and
+// an error is returned if any batch failed.
+func (c Client) SendBulkMessageWithContext(ctx context.Context, req BulkMessageRequest) (BulkMessageResult, error) {
+	req.Channel = req.Channel.canonical()
+	if err := req.Validate(); err != nil {
+		return BulkMessageResult{}, err
+	}
+	if err := c.checkPages("BulkMessageRequest", req.Channel, req.Type, req.Sms); err != nil {
+		return BulkMessageResult{}, err
+	}
+	req.APIKey = c.config.APIKey
+
+	batches := splitRecipients(req.To, c.batchSize())
+	result := BulkMessageResult{Batches: make([]BulkBatchResult, len(batches))}
+
+	workers := c.bulkConcurrency
+	if workers <= 0 {
+		workers = defaultBulkConcurrency
+	}
+	if workers > len(batches) {
+		workers = len(batches)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				batch := req
+				batch.To = batches[i]
+				result.Batches[i] = BulkBatchResult{Index: i, Recipients: batches[i]}
+				result.Batches[i].Response, result.Batches[i].Err = c.sendBulkBatch(ctx, batch)
+			}
+		}()
+	}
+
+	for i := range batches {
+		if ctx.Err() != nil {
+			result.Batches[i] = BulkBatchResult{Index: i, Recipients: batches[i], Err: ctx.Err()}
+			continue
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var firstErr error
+	failedBatches := 0
+	for _, b := range result.Batches {
+		if b.Err != nil {
+			result.Failed += len(b.Recipients)
+			failedBatches++
+			if firstErr == nil {
+				firstErr = b.Err
+			}
+			continue
+		}
+		result.Sent += len(b.Recipients)
+	}
+	if firstErr != nil {
+		return result, errors.Wrapf(firstErr, "%d of %d bulk message batches failed, first error", failedBatches, len(batches))
+	}
+	return result, nil
+}
+
+func (c Client) sendBulkBatch(ctx context.Context, req BulkMessageRequest) (BulkMessageResponse, error) {
+	rURL := string(EndpointSendBulkMessage)
+
+	var Response BulkMessageResponse
+	if err := c.makeRequest(ctx, http.MethodPost, EndpointSendBulkMessage, rURL, req, &Response); err != nil {
+		return BulkMessageResponse{}, errors.Wrap(err, "error in making request to send bulk message")
+	}
+	c.messagesSent(ctx, string(req.Channel), len(req.To))
+	return Response, nil
+}
+
+func (c Client) batchSize() int {
+	if c.bulkBatchSize <= 0 || c.bulkBatchSize > MaxBulkRecipients {
+		return MaxBulkRecipients
+	}
+	return c.bulkBatchSize
+}
+
+func splitRecipients(to []string, size int) [][]string {
+	var batches [][]string
+	for start := 0; start < len(to); start += size {
+		end := start + size
+		if end > len(to) {
+			end = len(to)
+		}
+		batches = append(batches, to[start:end])
+	}
+	return batches
+}
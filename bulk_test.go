@@ -0,0 +1,130 @@
+package gotermii_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	termii "github.com/Uchencho/go-termii"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendBulkMessageSuccess(t *testing.T) {
+	os.Setenv("TERMII_API_KEY", termiiTestApiKey)
+	var (
+		expectedRequest termii.BulkMessageRequest
+		receivedBody    termii.BulkMessageRequest
+		req             termii.BulkMessageRequest
+		expectedResult  termii.BulkMessageResponse
+	)
+
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := json.NewDecoder(req.Body).Decode(&receivedBody); err != nil {
+			log.Printf("error in unmarshalling %+v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		t.Run("URL and request method is as expected", func(t *testing.T) {
+			expectedURL := "/api/sms/send/bulk"
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, expectedURL, req.RequestURI)
+		})
+
+		t.Run("Request is as expected", func(t *testing.T) {
+			fileToStruct(filepath.Join("testdata", "send_bulk_message_request.json"), &expectedRequest)
+			assert.Equal(t, expectedRequest, receivedBody)
+		})
+
+		var resp termii.BulkMessageResponse
+		fileToStruct(filepath.Join("testdata", "send_bulk_message_response.json"), &resp)
+
+		w.WriteHeader(http.StatusOK)
+		bb, _ := json.Marshal(resp)
+		w.Write(bb)
+	}))
+	defer termiiService.Close()
+	os.Setenv("TERMII_URL", termiiService.URL)
+	fileToStruct(filepath.Join("testdata", "send_bulk_message_request.json"), &req)
+
+	c := termii.NewClient()
+
+	result, err := c.SendBulkMessage(req)
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Result is as expected", func(t *testing.T) {
+		fileToStruct(filepath.Join("testdata", "send_bulk_message_response.json"), &expectedResult)
+		assert.Len(t, result.Batches, 1)
+		assert.Equal(t, expectedResult, result.Batches[0].Response)
+		assert.Equal(t, 3, result.Sent)
+		assert.Equal(t, []string{expectedResult.MessageID}, result.MessageIDs())
+	})
+}
+
+func TestSendBulkMessageBatches(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received [][]string
+	)
+
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body termii.BulkMessageRequest
+		json.NewDecoder(req.Body).Decode(&body)
+
+		mu.Lock()
+		received = append(received, body.To)
+		mu.Unlock()
+
+		// fail the batch starting with the fifth recipient
+		if body.To[0] == "234000000004" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message":"Invalid recipient"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fmt.Sprintf(`{"code":"ok","message_id":"id-%s","message":"Successfully Sent"}`, body.To[0])))
+	}))
+	defer termiiService.Close()
+
+	c, _ := termii.New(
+		termii.WithAPIKey(termiiTestApiKey),
+		termii.WithBaseURL(termiiService.URL),
+		termii.WithBulkBatchSize(2),
+		termii.WithBulkConcurrency(2),
+	)
+
+	var to []string
+	for i := 0; i < 7; i++ {
+		to = append(to, fmt.Sprintf("23400000000%d", i))
+	}
+
+	result, err := c.SendBulkMessage(termii.BulkMessageRequest{To: to, From: "talert", Sms: "Hi", Type: "plain", Channel: "generic"})
+	t.Run("Error is returned for the failed batch", func(t *testing.T) {
+		assert.Error(t, err)
+		assert.Len(t, result.FailedBatches(), 1)
+		assert.Equal(t, []string{"234000000004", "234000000005"}, result.FailedBatches()[0].Recipients)
+	})
+
+	t.Run("Recipients are split into batches", func(t *testing.T) {
+		assert.Len(t, received, 4)
+		assert.Len(t, result.Batches, 4)
+		assert.Equal(t, 5, result.Sent)
+		assert.Equal(t, 2, result.Failed)
+	})
+
+	t.Run("Message ids of successful batches are reported", func(t *testing.T) {
+		ids := result.MessageIDs()
+		sort.Strings(ids)
+		assert.Equal(t, []string{"id-234000000000", "id-234000000002", "id-234000000006"}, ids)
+	})
+}
@@ -0,0 +1,210 @@
+package gotermii
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Campaign types
+const (
+	CampaignTypeRegular      = "regular"
+	CampaignTypePersonalized = "personalized"
+)
+
+// campaignScheduleLayout is the layout of the schedule time of a campaign, e.g 30-06-2021 6:00
+const campaignScheduleLayout = "02-01-2006 15:04"
+
+// SendCampaignRequest is a representation of a send campaign request.
+//
+// Personalized campaigns replace placeholders such as {first_name} in Message with the
+// details of each contact in the phonebook. A campaign is scheduled by setting ScheduleAt,
+// or ScheduleTime and ScheduleSmsStatus directly.
+type SendCampaignRequest struct {
+	APIKey             string  `json:"api_key"`
+	CountryCode        string  `json:"country_code"`
+	SenderID           string  `json:"sender_id"`
+	Message            string  `json:"message"`
+	Channel            Channel `json:"channel"`
+	MessageType        string  `json:"message_type"`
+	PhonebookID        string  `json:"phonebook_id"`
+	Delimiter          string  `json:"delimiter,omitempty"`
+	RemoveDuplicate    string  `json:"remove_duplicate,omitempty"`
+	CampaignType       string  `json:"campaign_type"`
+	ScheduleTime       string  `json:"schedule_time,omitempty"`
+	ScheduleSmsStatus  string  `json:"schedule_sms_status,omitempty"`
+	Timezone           string  `json:"timezone,omitempty"`
+	EnableLinkTracking bool    `json:"enable_link_tracking,omitempty"`
+
+	// ScheduleAt schedules the campaign, it takes precedence over ScheduleTime. It is converted to
+	// Timezone when set, otherwise Timezone is set from its location, or to UTC when its location
+	// is Local or has no IANA name
+	ScheduleAt time.Time `json:"-"`
+}
+
+// SendCampaignResponse is a representation of a send campaign response
+type SendCampaignResponse struct {
+	Message    string `json:"message"`
+	CampaignID string `json:"campaignId"`
+	Status     string `json:"status"`
+}
+
+// Campaign is a representation of a campaign
+type Campaign struct {
+	CampaignID      string `json:"campaign_id"`
+	PhoneBook       string `json:"phone_book"`
+	Sender          string `json:"sender"`
+	CampType        string `json:"camp_type"`
+	Channel         string `json:"channel"`
+	TotalRecipients int    `json:"total_recipients"`
+	RunAt           string `json:"run_at"`
+	Status          string `json:"status"`
+	CreatedAt       string `json:"created_at"`
+}
+
+// ListCampaignsResponse is a representation of a list campaigns response
+type ListCampaignsResponse struct {
+	Data  []Campaign      `json:"data"`
+	Links PaginationLinks `json:"links"`
+	Meta  PaginationMeta  `json:"meta"`
+}
+
+// CampaignMessage is a representation of a message sent as part of a campaign
+type CampaignMessage struct {
+	ID                  int         `json:"id"`
+	Sender              string      `json:"sender"`
+	Receiver            string      `json:"receiver"`
+	Message             string      `json:"message"`
+	MessageAbbreviation string      `json:"message_abbreviation"`
+	Amount              interface{} `json:"amount"`
+	Channel             string      `json:"channel"`
+	SmsType             string      `json:"sms_type"`
+	MessageID           string      `json:"message_id"`
+	Status              string      `json:"status"`
+	DateCreated         string      `json:"date_created"`
+	LastUpdated         string      `json:"last_updated"`
+}
+
+// CampaignHistoryResponse is a representation of a campaign history response
+type CampaignHistoryResponse struct {
+	Data  []CampaignMessage `json:"data"`
+	Links PaginationLinks   `json:"links"`
+	Meta  PaginationMeta    `json:"meta"`
+}
+
+// SendCampaign sends, or schedules, a message to every contact in a phonebook.
+// See docs https://developers.termii.com/campaign#send-a-campaign for more details
+func (c Client) SendCampaign(req SendCampaignRequest) (SendCampaignResponse, error) {
+	return c.SendCampaignWithContext(context.Background(), req)
+}
+
+// SendCampaignWithContext is like SendCampaign but carries ctx through to the underlying http request
+func (c Client) SendCampaignWithContext(ctx context.Context, req SendCampaignRequest) (SendCampaignResponse, error) {
+	rURL := string(EndpointSendCampaign)
+	req.APIKey = c.config.APIKey
+	if req.CampaignType == "" {
+		req.CampaignType = CampaignTypeRegular
+	}
+	if !req.ScheduleAt.IsZero() {
+		at, timezone, err := campaignSchedule(req.ScheduleAt, req.Timezone)
+		if err != nil {
+			return SendCampaignResponse{}, err
+		}
+		req.ScheduleTime = at.Format(campaignScheduleLayout)
+		req.ScheduleSmsStatus = "scheduled"
+		req.Timezone = timezone
+	}
+
+	var Response SendCampaignResponse
+	if err := c.makeRequest(ctx, http.MethodPost, EndpointSendCampaign, rURL, req, &Response); err != nil {
+		return SendCampaignResponse{}, errors.Wrap(err, "error in making request to send campaign")
+	}
+	return Response, nil
+}
+
+// campaignSchedule returns at in the timezone the campaign is scheduled in and the name of that timezone
+func campaignSchedule(at time.Time, timezone string) (time.Time, string, error) {
+	if timezone == "" {
+		name := at.Location().String()
+		if _, err := time.LoadLocation(name); err != nil || at.Location() == time.Local {
+			return at.UTC(), "UTC", nil
+		}
+		return at, name, nil
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		v := validator{request: "SendCampaignRequest"}
+		v.addf("timezone", "has unknown value %q", timezone)
+		return time.Time{}, "", v.err()
+	}
+	return at.In(loc), timezone, nil
+}
+
+// ListCampaigns returns the first page of campaigns.
+// See docs https://developers.termii.com/campaign#fetch-campaigns for more details
+func (c Client) ListCampaigns() (ListCampaignsResponse, error) {
+	return c.ListCampaignsPageWithContext(context.Background(), 1)
+}
+
+// ListCampaignsWithContext is like ListCampaigns but carries ctx through to the underlying http request
+func (c Client) ListCampaignsWithContext(ctx context.Context) (ListCampaignsResponse, error) {
+	return c.ListCampaignsPageWithContext(ctx, 1)
+}
+
+// ListCampaignsPage returns a page of campaigns, pages start at 1
+func (c Client) ListCampaignsPage(page int) (ListCampaignsResponse, error) {
+	return c.ListCampaignsPageWithContext(context.Background(), page)
+}
+
+// ListCampaignsPageWithContext is like ListCampaignsPage but carries ctx through to the underlying http request
+func (c Client) ListCampaignsPageWithContext(ctx context.Context, page int) (ListCampaignsResponse, error) {
+	rURL := fmt.Sprintf("%s?api_key=%s", EndpointCampaigns, c.config.APIKey)
+	if page > 1 {
+		rURL = fmt.Sprintf("%s&page=%d", rURL, page)
+	}
+
+	var Response ListCampaignsResponse
+	if err := c.makeRequest(ctx, http.MethodGet, EndpointCampaigns, rURL, nil, &Response); err != nil {
+		return ListCampaignsResponse{}, errors.Wrap(err, "error in making request to list campaigns")
+	}
+	return Response, nil
+}
+
+// GetCampaignHistory returns the first page of messages sent by a campaign.
+// See docs https://developers.termii.com/campaign#fetch-campaign-history for more details
+func (c Client) GetCampaignHistory(campaignID string) (CampaignHistoryResponse, error) {
+	return c.GetCampaignHistoryPageWithContext(context.Background(), campaignID, 1)
+}
+
+// GetCampaignHistoryWithContext is like GetCampaignHistory but carries ctx through to the underlying http request
+func (c Client) GetCampaignHistoryWithContext(ctx context.Context, campaignID string) (CampaignHistoryResponse, error) {
+	return c.GetCampaignHistoryPageWithContext(ctx, campaignID, 1)
+}
+
+// GetCampaignHistoryPage returns a page of messages sent by a campaign, pages start at 1
+func (c Client) GetCampaignHistoryPage(campaignID string, page int) (CampaignHistoryResponse, error) {
+	return c.GetCampaignHistoryPageWithContext(context.Background(), campaignID, page)
+}
+
+// GetCampaignHistoryPageWithContext is like GetCampaignHistoryPage but carries ctx through to the underlying http request
+func (c Client) GetCampaignHistoryPageWithContext(ctx context.Context, campaignID string, page int) (CampaignHistoryResponse, error) {
+	if campaignID == "" {
+		return CampaignHistoryResponse{}, errors.New("campaign id is required")
+	}
+	path := strings.Replace(string(EndpointCampaign), "{campaign_id}", url.PathEscape(campaignID), 1)
+	rURL := fmt.Sprintf("%s?api_key=%s", path, c.config.APIKey)
+	if page > 1 {
+		rURL = fmt.Sprintf("%s&page=%d", rURL, page)
+	}
+
+	var Response CampaignHistoryResponse
+	if err := c.makeRequest(ctx, http.MethodGet, EndpointCampaign, rURL, nil, &Response); err != nil {
+		return CampaignHistoryResponse{}, errors.Wrap(err, "error in making request to get campaign history")
+	}
+	return Response, nil
+}
@@ -0,0 +1,191 @@
+package gotermii
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Contact is a representation of a contact in a phonebook
+type Contact struct {
+	ID           int         `json:"id"`
+	PID          int         `json:"pid"`
+	PhoneNumber  string      `json:"phone_number"`
+	EmailAddress string      `json:"email_address"`
+	Message      interface{} `json:"message"`
+	Company      string      `json:"company"`
+	FirstName    string      `json:"first_name"`
+	LastName     string      `json:"last_name"`
+	CreateAt     string      `json:"create_at"`
+	UpdatedAt    string      `json:"updated_at"`
+}
+
+// ListContactsResponse is a representation of a list contacts response
+type ListContactsResponse struct {
+	Data  []Contact       `json:"data"`
+	Links PaginationLinks `json:"links"`
+	Meta  PaginationMeta  `json:"meta"`
+}
+
+// AddContactRequest is a representation of an add contact request
+type AddContactRequest struct {
+	APIKey       string `json:"api_key"`
+	PhoneNumber  string `json:"phone_number"`
+	CountryCode  string `json:"country_code"`
+	EmailAddress string `json:"email_address"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	Company      string `json:"company"`
+}
+
+// AddContactResponse is a representation of an add contact response
+type AddContactResponse struct {
+	Data Contact `json:"data"`
+}
+
+// DeleteContactResponse is a representation of a delete contact response
+type DeleteContactResponse struct {
+	Message string `json:"message"`
+}
+
+// UploadContactsRequest is a representation of a request to upload contacts from a csv file.
+// File is streamed to termii as it is read, so it can be an open file or any other reader.
+type UploadContactsRequest struct {
+	PhonebookID string
+	// CountryCode is the dialing code, e.g 234, applied to numbers in the file without one
+	CountryCode string
+	// FileName defaults to contacts.csv
+	FileName string
+	File     io.Reader
+}
+
+// UploadContactsResponse is a representation of an upload contacts response
+type UploadContactsResponse struct {
+	Message string `json:"message"`
+}
+
+// ListContacts returns the first page of contacts in a phonebook.
+// See docs https://developers.termii.com/contacts#fetch-contacts-by-phonebook-id for more details
+func (c Client) ListContacts(phonebookID string) (ListContactsResponse, error) {
+	return c.ListContactsPageWithContext(context.Background(), phonebookID, 1)
+}
+
+// ListContactsWithContext is like ListContacts but carries ctx through to the underlying http request
+func (c Client) ListContactsWithContext(ctx context.Context, phonebookID string) (ListContactsResponse, error) {
+	return c.ListContactsPageWithContext(ctx, phonebookID, 1)
+}
+
+// ListContactsPage returns a page of contacts in a phonebook, pages start at 1
+func (c Client) ListContactsPage(phonebookID string, page int) (ListContactsResponse, error) {
+	return c.ListContactsPageWithContext(context.Background(), phonebookID, page)
+}
+
+// ListContactsPageWithContext is like ListContactsPage but carries ctx through to the underlying http request
+func (c Client) ListContactsPageWithContext(ctx context.Context, phonebookID string, page int) (ListContactsResponse, error) {
+	if phonebookID == "" {
+		return ListContactsResponse{}, errors.New("phonebook id is required")
+	}
+	rURL := fmt.Sprintf("%s?api_key=%s", contactsURL(phonebookID), c.config.APIKey)
+	if page > 1 {
+		rURL = fmt.Sprintf("%s&page=%d", rURL, page)
+	}
+
+	var Response ListContactsResponse
+	if err := c.makeRequest(ctx, http.MethodGet, EndpointContacts, rURL, nil, &Response); err != nil {
+		return ListContactsResponse{}, errors.Wrap(err, "error in making request to list contacts")
+	}
+	return Response, nil
+}
+
+// AddContact adds a single contact to a phonebook.
+// See docs https://developers.termii.com/contacts#add-single-contacts-to-phonebook for more details
+func (c Client) AddContact(phonebookID string, req AddContactRequest) (AddContactResponse, error) {
+	return c.AddContactWithContext(context.Background(), phonebookID, req)
+}
+
+// AddContactWithContext is like AddContact but carries ctx through to the underlying http request
+func (c Client) AddContactWithContext(ctx context.Context, phonebookID string, req AddContactRequest) (AddContactResponse, error) {
+	if phonebookID == "" {
+		return AddContactResponse{}, errors.New("phonebook id is required")
+	}
+	rURL := contactsURL(phonebookID)
+	req.APIKey = c.config.APIKey
+
+	var Response AddContactResponse
+	if err := c.makeRequest(ctx, http.MethodPost, EndpointContacts, rURL, req, &Response); err != nil {
+		return AddContactResponse{}, errors.Wrap(err, "error in making request to add contact")
+	}
+	return Response, nil
+}
+
+// DeleteContact deletes a contact from a phonebook.
+// See docs https://developers.termii.com/contacts#delete-contact for more details
+func (c Client) DeleteContact(phonebookID, contactID string) (DeleteContactResponse, error) {
+	return c.DeleteContactWithContext(context.Background(), phonebookID, contactID)
+}
+
+// DeleteContactWithContext is like DeleteContact but carries ctx through to the underlying http request
+func (c Client) DeleteContactWithContext(ctx context.Context, phonebookID, contactID string) (DeleteContactResponse, error) {
+	if phonebookID == "" || contactID == "" {
+		return DeleteContactResponse{}, errors.New("phonebook id and contact id are required")
+	}
+	rURL := fmt.Sprintf("%s/%s?api_key=%s", contactsURL(phonebookID), url.PathEscape(contactID), c.config.APIKey)
+
+	var Response DeleteContactResponse
+	if err := c.makeRequest(ctx, http.MethodDelete, EndpointContact, rURL, nil, &Response); err != nil {
+		return DeleteContactResponse{}, errors.Wrap(err, "error in making request to delete contact")
+	}
+	return Response, nil
+}
+
+// UploadContacts adds the contacts of a csv file to a phonebook. Termii processes the file in the background.
+// See docs https://developers.termii.com/contacts#add-multiple-contacts-to-phonebook for more details
+func (c Client) UploadContacts(req UploadContactsRequest) (UploadContactsResponse, error) {
+	return c.UploadContactsWithContext(context.Background(), req)
+}
+
+// UploadContactsWithContext is like UploadContacts but carries ctx through to the underlying http request
+func (c Client) UploadContactsWithContext(ctx context.Context, req UploadContactsRequest) (UploadContactsResponse, error) {
+	if req.PhonebookID == "" {
+		return UploadContactsResponse{}, errors.New("phonebook id is required")
+	}
+	if req.File == nil {
+		return UploadContactsResponse{}, errors.New("contacts file is required")
+	}
+	fileName := req.FileName
+	if fileName == "" {
+		fileName = "contacts.csv"
+	}
+	rURL := string(EndpointUploadContacts)
+	fields := map[string]string{
+		"api_key":      c.config.APIKey,
+		"pid":          req.PhonebookID,
+		"country_code": req.CountryCode,
+	}
+
+	var Response UploadContactsResponse
+	file := multipartFile{field: "file", filename: fileName, content: req.File}
+	if err := c.makeMultipartRequest(ctx, EndpointUploadContacts, rURL, fields, file, &Response); err != nil {
+		return UploadContactsResponse{}, errors.Wrap(err, "error in making request to upload contacts")
+	}
+	return Response, nil
+}
+
+// UploadContactsCSV streams csv into a phonebook, numbers without a dialing code are assumed to be in countryCode
+func (c Client) UploadContactsCSV(phonebookID, countryCode string, csv io.Reader) (UploadContactsResponse, error) {
+	return c.UploadContactsCSVWithContext(context.Background(), phonebookID, countryCode, csv)
+}
+
+// UploadContactsCSVWithContext is like UploadContactsCSV but carries ctx through to the underlying http request
+func (c Client) UploadContactsCSVWithContext(ctx context.Context, phonebookID, countryCode string, csv io.Reader) (UploadContactsResponse, error) {
+	return c.UploadContactsWithContext(ctx, UploadContactsRequest{PhonebookID: phonebookID, CountryCode: countryCode, File: csv})
+}
+
+func contactsURL(phonebookID string) string {
+	return strings.Replace(string(EndpointContacts), "{phonebook_id}", url.PathEscape(phonebookID), 1)
+}
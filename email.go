@@ -0,0 +1,45 @@
+package gotermii
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// EmailTokenRequest is a representation of a send email token request. Code is generated by the
+// caller, EmailConfigurationID is the id of the email configuration set up on the termii dashboard.
+type EmailTokenRequest struct {
+	APIKey               string `json:"api_key"`
+	EmailAddress         string `json:"email_address"`
+	Code                 string `json:"code"`
+	EmailConfigurationID string `json:"email_configuration_id"`
+}
+
+// EmailTokenResponse is a representation of a send email token response
+type EmailTokenResponse struct {
+	Code      string      `json:"code"`
+	MessageID string      `json:"message_id"`
+	Message   string      `json:"message"`
+	Balance   interface{} `json:"balance"`
+	User      string      `json:"user"`
+}
+
+// SendEmailToken sends a one time code, generated by the caller, to an email address.
+// See docs https://developers.termii.com/email-token for more details
+func (c Client) SendEmailToken(req EmailTokenRequest) (EmailTokenResponse, error) {
+	return c.SendEmailTokenWithContext(context.Background(), req)
+}
+
+// SendEmailTokenWithContext is like SendEmailToken but carries ctx through to the underlying http request
+func (c Client) SendEmailTokenWithContext(ctx context.Context, req EmailTokenRequest) (EmailTokenResponse, error) {
+	req.APIKey = c.config.APIKey
+	rURL := string(EndpointSendEmailToken)
+
+	var tokenResponse EmailTokenResponse
+	if err := c.makeRequest(ctx, http.MethodPost, EndpointSendEmailToken, rURL, req, &tokenResponse); err != nil {
+		return EmailTokenResponse{}, errors.Wrap(err, "error in making request to send email token")
+	}
+	c.messagesSent(ctx, string(ChannelEmail), 1)
+	return tokenResponse, nil
+}
@@ -0,0 +1,35 @@
+package gotermii
+
+// Endpoint identifies a termii api endpoint by its path, relative to the base url.
+// Path parameters are written as {name}, e.g api/phonebooks/{phonebook_id}. Endpoints
+// serving several operations, such as listing and creating phonebooks, are told apart
+// by their http method.
+type Endpoint string
+
+// Endpoints supported by the client
+const (
+	EndpointFetchSenderID            Endpoint = "api/sender-id"
+	EndpointRegisterSender           Endpoint = "api/sender-id/request"
+	EndpointSendMessage              Endpoint = "api/sms/send"
+	EndpointSendBulkMessage          Endpoint = "api/sms/send/bulk"
+	EndpointSendAutoGeneratedMessage Endpoint = "api/sms/number/send"
+	EndpointSetDeviceTemplate        Endpoint = "api/send/template"
+	EndpointSendToken                Endpoint = "api/sms/otp/send"
+	EndpointVerifyToken              Endpoint = "api/sms/otp/verify"
+	EndpointGetInAppToken            Endpoint = "api/sms/otp/generate"
+	EndpointSendVoiceToken           Endpoint = "api/sms/otp/send/voice"
+	EndpointSendVoiceCall            Endpoint = "api/sms/otp/call"
+	EndpointSendEmailToken           Endpoint = "api/email/otp/send"
+	EndpointGetBalance               Endpoint = "api/get-balance"
+	EndpointVerifyNumber             Endpoint = "api/check/dnd"
+	EndpointGetStatus                Endpoint = "api/insight/number/query"
+	EndpointGetHistory               Endpoint = "api/sms/inbox"
+	EndpointPhonebooks               Endpoint = "api/phonebooks"
+	EndpointPhonebook                Endpoint = "api/phonebooks/{phonebook_id}"
+	EndpointContacts                 Endpoint = "api/phonebooks/{phonebook_id}/contacts"
+	EndpointContact                  Endpoint = "api/phonebooks/{phonebook_id}/contacts/{contact_id}"
+	EndpointUploadContacts           Endpoint = "api/phonebooks/contacts/upload"
+	EndpointSendCampaign             Endpoint = "api/sms/campaigns/send"
+	EndpointCampaigns                Endpoint = "api/sms/campaigns"
+	EndpointCampaign                 Endpoint = "api/sms/campaigns/{campaign_id}"
+)
@@ -0,0 +1,203 @@
+package gotermii
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Channel is a representation of the route a message or token is sent through
+type Channel string
+
+// Channels
+const (
+	ChannelGeneric  Channel = "generic"
+	ChannelDND      Channel = "dnd"
+	ChannelWhatsApp Channel = "whatsapp"
+	ChannelEmail    Channel = "email"
+)
+
+// SMSType is a representation of the kind of message sent
+type SMSType string
+
+// SMS types
+const (
+	SMSTypePlain   SMSType = "plain"
+	SMSTypeUnicode SMSType = "unicode"
+)
+
+// MessageType is a representation of the type of a token message
+type MessageType string
+
+// Message types
+const (
+	MessageTypeNumeric      MessageType = "NUMERIC"
+	MessageTypeAlphanumeric MessageType = "ALPHANUMERIC"
+)
+
+// PinType is a representation of the characters a pin is made of
+type PinType string
+
+// Pin types
+const (
+	PinTypeNumeric      PinType = "NUMERIC"
+	PinTypeAlphanumeric PinType = "ALPHANUMERIC"
+)
+
+var (
+	channels     = []string{string(ChannelGeneric), string(ChannelDND), string(ChannelWhatsApp), string(ChannelEmail)}
+	smsTypes     = []string{string(SMSTypePlain), string(SMSTypeUnicode)}
+	messageTypes = []string{string(MessageTypeNumeric), string(MessageTypeAlphanumeric)}
+	pinTypes     = []string{string(PinTypeNumeric), string(PinTypeAlphanumeric)}
+)
+
+// canonical returns the value of known matching s, ignoring case and surrounding whitespace
+func canonical(s string, known []string) (string, bool) {
+	s = strings.TrimSpace(s)
+	for _, k := range known {
+		if strings.EqualFold(s, k) {
+			return k, true
+		}
+	}
+	return s, false
+}
+
+func unmarshalEnum(data []byte, known []string, name string) (string, error) {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return "", errors.Wrapf(err, "%s must be a string", name)
+	}
+	if s == "" {
+		return "", nil
+	}
+	v, ok := canonical(s, known)
+	if !ok {
+		return "", errors.Errorf("unknown %s %q, expected one of %s", name, s, strings.Join(known, ", "))
+	}
+	return v, nil
+}
+
+// ParseChannel returns the channel matching s, ignoring case and surrounding whitespace
+func ParseChannel(s string) (Channel, error) {
+	v, ok := canonical(s, channels)
+	if !ok {
+		return "", errors.Errorf("unknown channel %q, expected one of %s", s, strings.Join(channels, ", "))
+	}
+	return Channel(v), nil
+}
+
+// Valid reports whether c is a known channel
+func (c Channel) Valid() bool {
+	_, ok := canonical(string(c), channels)
+	return ok
+}
+
+// canonical returns the canonical spelling of c, unknown channels are returned trimmed
+func (c Channel) canonical() Channel {
+	v, _ := canonical(string(c), channels)
+	return Channel(v)
+}
+
+// MarshalJSON writes the canonical spelling of the channel
+func (c Channel) MarshalJSON() ([]byte, error) {
+	v, _ := canonical(string(c), channels)
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON reads a channel, rejecting unknown values
+func (c *Channel) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data, channels, "channel")
+	*c = Channel(v)
+	return err
+}
+
+// ParseSMSType returns the sms type matching s, ignoring case and surrounding whitespace
+func ParseSMSType(s string) (SMSType, error) {
+	v, ok := canonical(s, smsTypes)
+	if !ok {
+		return "", errors.Errorf("unknown sms type %q, expected one of %s", s, strings.Join(smsTypes, ", "))
+	}
+	return SMSType(v), nil
+}
+
+// Valid reports whether t is a known sms type
+func (t SMSType) Valid() bool {
+	_, ok := canonical(string(t), smsTypes)
+	return ok
+}
+
+// canonical returns the canonical spelling of t, unknown sms types are returned trimmed
+func (t SMSType) canonical() SMSType {
+	v, _ := canonical(string(t), smsTypes)
+	return SMSType(v)
+}
+
+// MarshalJSON writes the canonical spelling of the sms type
+func (t SMSType) MarshalJSON() ([]byte, error) {
+	v, _ := canonical(string(t), smsTypes)
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON reads an sms type, rejecting unknown values
+func (t *SMSType) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data, smsTypes, "sms type")
+	*t = SMSType(v)
+	return err
+}
+
+// ParseMessageType returns the message type matching s, ignoring case and surrounding whitespace
+func ParseMessageType(s string) (MessageType, error) {
+	v, ok := canonical(s, messageTypes)
+	if !ok {
+		return "", errors.Errorf("unknown message type %q, expected one of %s", s, strings.Join(messageTypes, ", "))
+	}
+	return MessageType(v), nil
+}
+
+// Valid reports whether t is a known message type
+func (t MessageType) Valid() bool {
+	_, ok := canonical(string(t), messageTypes)
+	return ok
+}
+
+// MarshalJSON writes the canonical spelling of the message type
+func (t MessageType) MarshalJSON() ([]byte, error) {
+	v, _ := canonical(string(t), messageTypes)
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON reads a message type, rejecting unknown values
+func (t *MessageType) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data, messageTypes, "message type")
+	*t = MessageType(v)
+	return err
+}
+
+// ParsePinType returns the pin type matching s, ignoring case and surrounding whitespace
+func ParsePinType(s string) (PinType, error) {
+	v, ok := canonical(s, pinTypes)
+	if !ok {
+		return "", errors.Errorf("unknown pin type %q, expected one of %s", s, strings.Join(pinTypes, ", "))
+	}
+	return PinType(v), nil
+}
+
+// Valid reports whether t is a known pin type
+func (t PinType) Valid() bool {
+	_, ok := canonical(string(t), pinTypes)
+	return ok
+}
+
+// MarshalJSON writes the canonical spelling of the pin type
+func (t PinType) MarshalJSON() ([]byte, error) {
+	v, _ := canonical(string(t), pinTypes)
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON reads a pin type, rejecting unknown values
+func (t *PinType) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalEnum(data, pinTypes, "pin type")
+	*t = PinType(v)
+	return err
+}
@@ -0,0 +1,136 @@
+package gotermii_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	termii "github.com/Uchencho/go-termii"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnumJSON(t *testing.T) {
+	t.Run("Values are written in their canonical spelling", func(t *testing.T) {
+		bb, err := json.Marshal(termii.SendTokenRequest{
+			Channel:     "generic ",
+			MessageType: "Numeric",
+			PinType:     termii.PinTypeAlphanumeric,
+		})
+		assert.NoError(t, err)
+
+		var body map[string]interface{}
+		json.Unmarshal(bb, &body)
+		assert.Equal(t, "generic", body["channel"])
+		assert.Equal(t, "NUMERIC", body["message_type"])
+		assert.Equal(t, "ALPHANUMERIC", body["pin_type"])
+	})
+
+	t.Run("Known values are read", func(t *testing.T) {
+		var req termii.SendMessageRequest
+		err := json.Unmarshal([]byte(`{"channel":"WhatsApp","type":"plain"}`), &req)
+		assert.NoError(t, err)
+		assert.Equal(t, termii.ChannelWhatsApp, req.Channel)
+		assert.Equal(t, termii.SMSTypePlain, req.Type)
+	})
+
+	t.Run("Unknown values are rejected", func(t *testing.T) {
+		var req termii.GenerateTokenRequest
+		assert.Error(t, json.Unmarshal([]byte(`{"pin_type":"HEX"}`), &req))
+	})
+
+	t.Run("Values are parsed", func(t *testing.T) {
+		c, err := termii.ParseChannel(" DND")
+		assert.NoError(t, err)
+		assert.Equal(t, termii.ChannelDND, c)
+
+		_, err = termii.ParsePinType("numbers")
+		assert.Error(t, err)
+	})
+}
+
+func TestEnumValidation(t *testing.T) {
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		t.Error("invalid request should not reach termii")
+	}))
+	defer termiiService.Close()
+
+	c, _ := termii.New(termii.WithAPIKey(termiiTestApiKey), termii.WithBaseURL(termiiService.URL))
+
+	_, err := c.SendMessage(termii.SendMessageRequest{To: "2347880234567", From: "talert", Sms: "Hi", Type: "plain", Channel: "sms"})
+	t.Run("Unknown channel is rejected", func(t *testing.T) {
+		assert.Error(t, err)
+	})
+
+	_, err = c.SendToken(termii.SendTokenRequest{To: "2347880234567", Channel: termii.ChannelDND, MessageType: "NUMBER", PinType: termii.PinTypeNumeric})
+	t.Run("Unknown message type is rejected", func(t *testing.T) {
+		assert.Error(t, err)
+	})
+
+	_, err = c.GetInAppToken(termii.GenerateTokenRequest{PhoneNumber: "2347880234567", PinType: "digits"})
+	t.Run("Unknown pin type is rejected", func(t *testing.T) {
+		assert.Error(t, err)
+	})
+}
+
+func TestEnumMixedCase(t *testing.T) {
+	var received map[string]interface{}
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		json.NewDecoder(req.Body).Decode(&received)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer termiiService.Close()
+
+	table, _ := termii.LoadRateTable(strings.NewReader(`{"currency": "NGN", "rates": {"default": {"whatsapp": 2, "email": 1}}}`))
+	c, _ := termii.New(
+		termii.WithAPIKey(termiiTestApiKey),
+		termii.WithBaseURL(termiiService.URL),
+		termii.WithSMSPageLimit(1),
+		termii.WithRateTable(table),
+	)
+
+	whatsapp := termii.SendMessageRequest{To: "2347880234567", From: "Acme", Sms: strings.Repeat("a", 1000), Type: termii.SMSTypePlain, Channel: "WhatsApp"}
+	_, err := c.SendMessage(whatsapp)
+	t.Run("A mixed case whatsapp message is not limited to an sms length", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.NoError(t, whatsapp.Validate())
+		assert.Equal(t, "whatsapp", received["channel"])
+	})
+
+	estimate, err := c.EstimateCost(whatsapp)
+	t.Run("A mixed case whatsapp message is billed as a single page", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Equal(t, termii.ChannelWhatsApp, estimate.Channel)
+		assert.Equal(t, 1, estimate.Pages)
+		assert.Equal(t, 2.0, estimate.Total)
+	})
+
+	email := termii.SendTokenRequest{
+		EmailAddress:         "ada@example.com",
+		EmailConfigurationID: "0a53c416-fb0e-4bc9-b4b7-8f8c5b4c6ed8",
+		From:                 "Acme",
+		Channel:              " Email",
+		MessageType:          termii.MessageTypeNumeric,
+		PinType:              termii.PinTypeNumeric,
+		PinAttempts:          3,
+		PinTimeToLive:        5,
+		PinLength:            6,
+		PinPlaceholder:       "< 1234 >",
+		MessageText:          "Your pin is < 1234 >",
+	}
+	_, err = c.SendToken(email)
+	t.Run("A mixed case email token is sent to its email address", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.NoError(t, email.Validate())
+		assert.Equal(t, "email", received["channel"])
+	})
+
+	estimate, err = c.EstimateCost(email)
+	t.Run("A mixed case email token is billed at the email rate", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Equal(t, 1.0, estimate.Total)
+	})
+}
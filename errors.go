@@ -0,0 +1,128 @@
+package gotermii
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// requestIDHeaders are the response headers checked, in order, for a request id
+var requestIDHeaders = []string{"X-Request-Id", "X-Request-ID", "Request-Id", "X-Amzn-Requestid", "Cf-Ray"}
+
+// APIError is a representation of a non successful response returned by termii
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Endpoint   string `json:"-"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	RequestID  string `json:"-"`
+	// RetryAfter is the delay requested by termii through the Retry-After header, zero if absent
+	RetryAfter time.Duration `json:"-"`
+	Body       []byte        `json:"-"`
+}
+
+// Error returns a description of the failed request. The api key is never part of the description.
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("invalid status code received, expected 200/201/204, got %v, endpoint=%s", e.StatusCode, e.Endpoint)
+	if e.Code != "" {
+		msg = fmt.Sprintf("%s, code=%s", msg, e.Code)
+	}
+	if e.RequestID != "" {
+		msg = fmt.Sprintf("%s, request_id=%s", msg, e.RequestID)
+	}
+	return fmt.Sprintf("%s, with response body=%s", msg, e.Body)
+}
+
+// newAPIError builds an APIError from an unsuccessful http response and its already read body
+func newAPIError(res *http.Response, ep Endpoint, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		Endpoint:   string(ep),
+		RetryAfter: parseRetryAfter(res.Header.Get("Retry-After")),
+		Body:       body,
+	}
+	for _, h := range requestIDHeaders {
+		if id := res.Header.Get(h); id != "" {
+			apiErr.RequestID = id
+			break
+		}
+	}
+
+	// termii is not consistent about the type of "code", it is a string on most endpoints and a number on others
+	var payload struct {
+		Code    json.RawMessage `json:"code"`
+		Message interface{}     `json:"message"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return apiErr
+	}
+	if len(payload.Code) > 0 && !bytes.Equal(payload.Code, []byte("null")) {
+		var code string
+		if err := json.Unmarshal(payload.Code, &code); err != nil {
+			code = string(payload.Code)
+		}
+		apiErr.Code = code
+	}
+	switch m := payload.Message.(type) {
+	case string:
+		apiErr.Message = m
+	case nil:
+	default:
+		bb, _ := json.Marshal(m)
+		apiErr.Message = string(bb)
+	}
+	return apiErr
+}
+
+// AsAPIError returns the APIError wrapped in err, if any
+func AsAPIError(err error) (*APIError, bool) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// IsUnauthorized reports whether err was caused by termii rejecting the api key
+func IsUnauthorized(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden)
+}
+
+// IsInsufficientBalance reports whether err was caused by the termii wallet not having enough funds,
+// as reported by termii or predicted by PreflightCheck
+func IsInsufficientBalance(err error) bool {
+	if errors.Is(err, ErrInsufficientBalance) {
+		return true
+	}
+	apiErr, ok := AsAPIError(err)
+	if !ok {
+		return false
+	}
+	if apiErr.StatusCode == http.StatusPaymentRequired {
+		return true
+	}
+	msg := strings.ToLower(apiErr.Message)
+	return strings.Contains(msg, "insufficient balance") || strings.Contains(msg, "insufficient fund")
+}
+
+// IsRateLimited reports whether err was caused by termii throttling the api key or the
+// client side rate limiter refusing the request
+func IsRateLimited(err error) bool {
+	if errors.Is(err, ErrRateLimited) {
+		return true
+	}
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.StatusCode == http.StatusTooManyRequests
+}
+
+// IsNotFound reports whether err was caused by termii not finding the requested resource
+func IsNotFound(err error) bool {
+	apiErr, ok := AsAPIError(err)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
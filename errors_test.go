@@ -0,0 +1,93 @@
+package gotermii_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	termii "github.com/Uchencho/go-termii"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError(t *testing.T) {
+	os.Setenv("TERMII_API_KEY", termiiTestApiKey)
+
+	table := []struct {
+		name        string
+		status      int
+		body        string
+		code        string
+		message     string
+		check       func(error) bool
+		otherChecks []func(error) bool
+	}{
+		{
+			name:        "Invalid api key",
+			status:      http.StatusUnauthorized,
+			body:        `{"code":"401","message":"Unauthenticated"}`,
+			code:        "401",
+			message:     "Unauthenticated",
+			check:       termii.IsUnauthorized,
+			otherChecks: []func(error) bool{termii.IsRateLimited, termii.IsNotFound, termii.IsInsufficientBalance},
+		},
+		{
+			name:        "Insufficient balance",
+			status:      http.StatusBadRequest,
+			body:        `{"code":400,"message":"Insufficient balance, please top up"}`,
+			code:        "400",
+			message:     "Insufficient balance, please top up",
+			check:       termii.IsInsufficientBalance,
+			otherChecks: []func(error) bool{termii.IsUnauthorized, termii.IsRateLimited, termii.IsNotFound},
+		},
+		{
+			name:        "Rate limited",
+			status:      http.StatusTooManyRequests,
+			body:        `Too Many Attempts.`,
+			check:       termii.IsRateLimited,
+			otherChecks: []func(error) bool{termii.IsUnauthorized, termii.IsNotFound, termii.IsInsufficientBalance},
+		},
+		{
+			name:        "Not found",
+			status:      http.StatusNotFound,
+			body:        `{"message":"Not Found"}`,
+			message:     "Not Found",
+			check:       termii.IsNotFound,
+			otherChecks: []func(error) bool{termii.IsUnauthorized, termii.IsRateLimited, termii.IsInsufficientBalance},
+		},
+	}
+
+	for _, entry := range table {
+		termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("X-Request-Id", "req-123")
+			w.WriteHeader(entry.status)
+			w.Write([]byte(entry.body))
+		}))
+		os.Setenv("TERMII_URL", termiiService.URL)
+
+		c := termii.NewClient()
+		_, err := c.GetBalance()
+		termiiService.Close()
+
+		t.Run(fmt.Sprintf("%s - APIError is returned", entry.name), func(t *testing.T) {
+			apiErr, ok := termii.AsAPIError(err)
+			assert.True(t, ok)
+			assert.Equal(t, entry.status, apiErr.StatusCode)
+			assert.Equal(t, "api/get-balance", apiErr.Endpoint)
+			assert.Equal(t, entry.code, apiErr.Code)
+			assert.Equal(t, entry.message, apiErr.Message)
+			assert.Equal(t, "req-123", apiErr.RequestID)
+			assert.Equal(t, entry.body, string(apiErr.Body))
+			assert.NotContains(t, err.Error(), termiiTestApiKey)
+		})
+
+		t.Run(fmt.Sprintf("%s - Error is classified", entry.name), func(t *testing.T) {
+			assert.True(t, entry.check(err))
+			for _, other := range entry.otherChecks {
+				assert.False(t, other(err))
+			}
+		})
+	}
+}
@@ -5,4 +5,5 @@ go 1.15
 require (
 	github.com/pkg/errors v0.9.1
 	github.com/stretchr/testify v1.7.0
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -1,8 +1,14 @@
 package gotermii
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -87,23 +93,37 @@ type HistoryResponse struct {
 // GetBalance returns total balance and balance information from your wallet, such as currency.
 // See docs https://developers.termii.com/balance for more details
 func (c Client) GetBalance() (GetBalanceResponse, error) {
-	rURL := fmt.Sprintf("api/get-balance?api_key=%s", c.config.APIKey)
+	return c.GetBalanceWithContext(context.Background())
+}
+
+// GetBalanceWithContext is like GetBalance but carries ctx through to the underlying http request
+func (c Client) GetBalanceWithContext(ctx context.Context) (GetBalanceResponse, error) {
+	rURL := fmt.Sprintf("%s?api_key=%s", EndpointGetBalance, c.config.APIKey)
 
 	var Response GetBalanceResponse
-	if err := c.makeRequest(http.MethodGet, rURL, nil, &Response); err != nil {
+	if err := c.makeRequest(ctx, http.MethodGet, EndpointGetBalance, rURL, nil, &Response); err != nil {
 		return GetBalanceResponse{}, errors.Wrap(err, "error in making request to get balance")
 	}
+	c.balanceObserved(ctx, Response)
 	return Response, nil
 }
 
 // VerifyNumber allows businesses verify phone numbers and automatically detect their status
 // See docs https://developers.termii.com/search for more details
 func (c Client) VerifyNumber(req VerifyNumberRequest) (VerifyNumberResponse, error) {
-	rURL := "api/check/dnd"
+	return c.VerifyNumberWithContext(context.Background(), req)
+}
+
+// VerifyNumberWithContext is like VerifyNumber but carries ctx through to the underlying http request
+func (c Client) VerifyNumberWithContext(ctx context.Context, req VerifyNumberRequest) (VerifyNumberResponse, error) {
+	rURL := string(EndpointVerifyNumber)
 	req.APIKey = c.config.APIKey
+	if err := c.normalizePhone("VerifyNumberRequest", "phone_number", &req.PhoneNumber, ""); err != nil {
+		return VerifyNumberResponse{}, err
+	}
 
 	var Response VerifyNumberResponse
-	if err := c.makeRequest(http.MethodGet, rURL, req, &Response); err != nil {
+	if err := c.makeRequest(ctx, http.MethodGet, EndpointVerifyNumber, rURL, req, &Response); err != nil {
 		return VerifyNumberResponse{}, errors.Wrap(err, "error in making request to verify number")
 	}
 	return Response, nil
@@ -112,11 +132,19 @@ func (c Client) VerifyNumber(req VerifyNumberRequest) (VerifyNumberResponse, err
 // GetStatus allows businesses to detect if a number is fake or has ported to a new network.
 // See docs https://developers.termii.com/status for more details
 func (c Client) GetStatus(req StatusRequest) (StatusResponse, error) {
-	rURL := "api/insight/number/query"
+	return c.GetStatusWithContext(context.Background(), req)
+}
+
+// GetStatusWithContext is like GetStatus but carries ctx through to the underlying http request
+func (c Client) GetStatusWithContext(ctx context.Context, req StatusRequest) (StatusResponse, error) {
+	rURL := string(EndpointGetStatus)
 	req.APIKey = c.config.APIKey
+	if err := c.normalizePhone("StatusRequest", "phone_number", &req.PhoneNumber, req.CountryCode); err != nil {
+		return StatusResponse{}, err
+	}
 
 	var Response StatusResponse
-	if err := c.makeRequest(http.MethodGet, rURL, req, &Response); err != nil {
+	if err := c.makeRequest(ctx, http.MethodGet, EndpointGetStatus, rURL, req, &Response); err != nil {
 		return StatusResponse{}, errors.Wrap(err, "error in making request to get status")
 	}
 	return Response, nil
@@ -125,11 +153,118 @@ func (c Client) GetStatus(req StatusRequest) (StatusResponse, error) {
 // GetHistory returns reports for messages sent across the sms, voice & whatsapp channels.
 // See docs https://developers.termii.com/history for more details
 func (c Client) GetHistory() ([]HistoryResponse, error) {
-	rURL := fmt.Sprintf("api/sms/inbox?api_key=%s", c.config.APIKey)
+	return c.GetHistoryWithContext(context.Background())
+}
+
+// GetHistoryWithContext is like GetHistory but carries ctx through to the underlying http request
+func (c Client) GetHistoryWithContext(ctx context.Context) ([]HistoryResponse, error) {
+	rURL := fmt.Sprintf("%s?api_key=%s", EndpointGetHistory, c.config.APIKey)
 
 	var Response []HistoryResponse
-	if err := c.makeRequest(http.MethodGet, rURL, nil, &Response); err != nil {
+	if err := c.makeRequest(ctx, http.MethodGet, EndpointGetHistory, rURL, nil, &Response); err != nil {
 		return []HistoryResponse{}, errors.Wrap(err, "error in making request to get history")
 	}
 	return Response, nil
 }
+
+// Delivery statuses reported for a message, a message with a terminal status will not change status again
+const (
+	MessageStatusDelivered     = "Delivered"
+	MessageStatusFailed        = "Failed"
+	MessageStatusMessageFailed = "Message Failed"
+	MessageStatusRejected      = "Rejected"
+	MessageStatusExpired       = "Expired"
+	MessageStatusDNDActive     = "DND Active on Phone Number"
+)
+
+// terminalStatuses are the words found in the statuses of messages which will not change status again
+var terminalStatuses = []string{"delivered", "failed", "rejected", "expired"}
+
+// ErrMessageNotFound is returned when termii has no record of a message id
+var ErrMessageNotFound = errors.New("message not found")
+
+// IsTerminal reports whether the message has reached a status it will not move on from, such as
+// Delivered, Message Failed or DND Active on Phone Number. Statuses are matched regardless of case.
+func (h HistoryResponse) IsTerminal() bool {
+	status := strings.ToLower(strings.TrimSpace(h.Status))
+	if strings.HasPrefix(status, "dnd") {
+		return true
+	}
+	for _, terminal := range terminalStatuses {
+		if strings.Contains(status, terminal) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetMessageStatus returns the report of a single message sent across the sms, voice & whatsapp channels.
+// ErrMessageNotFound is returned if termii has no record of messageID.
+// See docs https://developers.termii.com/history for more details
+func (c Client) GetMessageStatus(messageID string) (HistoryResponse, error) {
+	return c.GetMessageStatusWithContext(context.Background(), messageID)
+}
+
+// GetMessageStatusWithContext is like GetMessageStatus but carries ctx through to the underlying http request
+func (c Client) GetMessageStatusWithContext(ctx context.Context, messageID string) (HistoryResponse, error) {
+	if messageID == "" {
+		return HistoryResponse{}, errors.New("message id is required")
+	}
+	rURL := fmt.Sprintf("%s?api_key=%s&message_id=%s", EndpointGetHistory, c.config.APIKey, url.QueryEscape(messageID))
+
+	var raw json.RawMessage
+	if err := c.makeRequest(ctx, http.MethodGet, EndpointGetHistory, rURL, nil, &raw); err != nil {
+		return HistoryResponse{}, errors.Wrap(err, "error in making request to get message status")
+	}
+
+	// the filtered inbox is usually a list, but a single record is accepted too
+	var records []HistoryResponse
+	if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '{' {
+		var record HistoryResponse
+		if err := json.Unmarshal(trimmed, &record); err != nil {
+			return HistoryResponse{}, errors.Wrap(err, "unable to unmarshal message status")
+		}
+		records = append(records, record)
+	} else if err := json.Unmarshal(raw, &records); err != nil {
+		return HistoryResponse{}, errors.Wrap(err, "unable to unmarshal message status")
+	}
+
+	for _, record := range records {
+		if record.MessageID == messageID {
+			return record, nil
+		}
+	}
+	return HistoryResponse{}, errors.Wrapf(ErrMessageNotFound, "message id %s", messageID)
+}
+
+// WaitForDelivery polls the status of a message every pollInterval until it reaches a terminal status
+// (see HistoryResponse.IsTerminal), ctx is done or a request fails. A message termii has no record of
+// yet, or whose status is not recognised, is polled again, so ctx should carry a deadline.
+// The last known report is returned along with any error.
+func (c Client) WaitForDelivery(ctx context.Context, messageID string, pollInterval time.Duration) (HistoryResponse, error) {
+	if pollInterval <= 0 {
+		return HistoryResponse{}, errors.New("poll interval must be positive")
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var last HistoryResponse
+	for {
+		record, err := c.GetMessageStatusWithContext(ctx, messageID)
+		switch {
+		case err == nil:
+			last = record
+			if record.IsTerminal() {
+				return record, nil
+			}
+		case !errors.Is(err, ErrMessageNotFound):
+			return last, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return last, errors.Wrapf(ctx.Err(), "gave up waiting for delivery of message %s", messageID)
+		case <-ticker.C:
+		}
+	}
+}
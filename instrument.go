@@ -0,0 +1,105 @@
+package gotermii
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Instrumentation is notified of the requests made by a client and of what termii reports back,
+// to record traces and metrics. The termiiotel module implements it with OpenTelemetry.
+// Implementations must be safe for concurrent use.
+type Instrumentation interface {
+	// StartCall is called before a request is sent. The returned context is carried through every
+	// attempt of the request and end is called once with the outcome of the request.
+	StartCall(ctx context.Context, call CallInfo) (_ context.Context, end func(CallResult))
+	// MessagesSent is called with the number of messages termii accepted over channel
+	MessagesSent(ctx context.Context, channel string, count int)
+	// TokenVerified is called with the outcome of every pin verification termii answered
+	TokenVerified(ctx context.Context, verified bool)
+	// BalanceObserved is called whenever termii reports the wallet balance
+	BalanceObserved(ctx context.Context, balance float64, currency string)
+}
+
+// CallInfo is a representation of a request about to be sent
+type CallInfo struct {
+	Method   string
+	Endpoint Endpoint
+}
+
+// CallResult is a representation of the outcome of a request, across all of its attempts
+type CallResult struct {
+	// StatusCode is the http status of the last attempt, 0 if termii did not respond
+	StatusCode int
+	// Code is the termii code of a failed request
+	Code string
+	// Attempts is the number of attempts made, retries are Attempts - 1
+	Attempts int
+	// Duration is the time taken by the request, including rate limiting and backoff
+	Duration time.Duration
+	Err      error
+	// Error is the text of Err with the api key redacted, it is safe to export
+	Error string
+}
+
+// Channels reported to MessagesSent besides the typed Channel values
+const (
+	// InstrumentChannelVoice is reported for voice tokens and calls
+	InstrumentChannelVoice = "voice"
+	// InstrumentChannelNumber is reported for messages sent from an auto generated number
+	InstrumentChannelNumber = "number"
+)
+
+// WithInstrumentation reports the requests made by the client, messages sent, pin verifications
+// and balances to inst
+func WithInstrumentation(inst Instrumentation) Option {
+	return func(c *Client) {
+		c.instrumentation = inst
+	}
+}
+
+func (s *Client) startCall(ctx context.Context, method string, ep Endpoint) (context.Context, func(CallResult)) {
+	if s.instrumentation == nil {
+		return ctx, func(CallResult) {}
+	}
+	return s.instrumentation.StartCall(ctx, CallInfo{Method: method, Endpoint: ep})
+}
+
+func newCallResult(status, attempts int, duration time.Duration, err error) CallResult {
+	result := CallResult{StatusCode: status, Attempts: attempts, Duration: duration, Err: err}
+	if err != nil {
+		result.Error = apiKeyParam.ReplaceAllString(err.Error(), "${1}"+redacted)
+	}
+	if apiErr, ok := AsAPIError(err); ok {
+		result.Code = apiErr.Code
+	}
+	return result
+}
+
+func (c Client) messagesSent(ctx context.Context, channel string, count int) {
+	if c.instrumentation != nil && count > 0 {
+		c.instrumentation.MessagesSent(ctx, channel, count)
+	}
+}
+
+// tokenVerified reports the outcome of a pin verification. Termii rejects wrong, expired and
+// exhausted pins with a client error, any other failure says nothing about the pin.
+func (c Client) tokenVerified(ctx context.Context, resp VerifyTokenResponse, err error) {
+	if c.instrumentation == nil {
+		return
+	}
+	if err == nil {
+		c.instrumentation.TokenVerified(ctx, resp.IsVerified())
+		return
+	}
+	if apiErr, ok := AsAPIError(err); ok && apiErr.StatusCode >= http.StatusBadRequest &&
+		apiErr.StatusCode < http.StatusInternalServerError && apiErr.StatusCode != http.StatusTooManyRequests {
+		c.instrumentation.TokenVerified(ctx, false)
+	}
+}
+
+func (c Client) balanceObserved(ctx context.Context, resp GetBalanceResponse) {
+	if c.instrumentation != nil {
+		c.instrumentation.BalanceObserved(ctx, float64(resp.Balance), resp.Currency)
+	}
+}
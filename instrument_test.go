@@ -0,0 +1,150 @@
+package gotermii_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	termii "github.com/Uchencho/go-termii"
+	"github.com/Uchencho/go-termii/termiitest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type callKey struct{}
+
+// recordingInstrumentation records everything it is notified of
+type recordingInstrumentation struct {
+	mu       sync.Mutex
+	calls    []termii.CallInfo
+	results  []termii.CallResult
+	sent     map[string]int
+	verified []bool
+	balances []float64
+}
+
+func (r *recordingInstrumentation) StartCall(ctx context.Context, call termii.CallInfo) (context.Context, func(termii.CallResult)) {
+	r.mu.Lock()
+	r.calls = append(r.calls, call)
+	r.mu.Unlock()
+	return context.WithValue(ctx, callKey{}, call.Endpoint), func(result termii.CallResult) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.results = append(r.results, result)
+	}
+}
+
+func (r *recordingInstrumentation) MessagesSent(ctx context.Context, channel string, count int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent[channel] += count
+}
+
+func (r *recordingInstrumentation) TokenVerified(ctx context.Context, verified bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.verified = append(r.verified, verified)
+}
+
+func (r *recordingInstrumentation) BalanceObserved(ctx context.Context, balance float64, currency string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.balances = append(r.balances, balance)
+}
+
+func TestInstrumentation(t *testing.T) {
+	srv := termiitest.NewServer(termiitest.WithBalance(100))
+	defer srv.Close()
+
+	inst := &recordingInstrumentation{sent: map[string]int{}}
+	var propagated []interface{}
+	c := srv.Client(
+		termii.WithInstrumentation(inst),
+		termii.WithRetryPolicy(termii.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}),
+		termii.WithMiddleware(func(next termii.Doer) termii.Doer {
+			return termii.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				propagated = append(propagated, req.Context().Value(callKey{}))
+				return next.Do(req)
+			})
+		}),
+	)
+
+	_, err := c.SendMessage(termii.SendMessageRequest{To: "2347880234567", From: "Acme", Sms: "Hi", Type: termii.SMSTypePlain, Channel: termii.ChannelGeneric})
+	assert.NoError(t, err)
+	_, err = c.SendBulkMessage(termii.BulkMessageRequest{To: []string{"2347880234567", "2348109077743"}, From: "Acme", Sms: "Hi", Type: termii.SMSTypePlain, Channel: termii.ChannelGeneric})
+	assert.NoError(t, err)
+
+	token, err := c.SendToken(termii.SendTokenRequest{
+		To:             "2348109077743",
+		From:           "Acme",
+		Channel:        termii.ChannelDND,
+		MessageType:    termii.MessageTypeNumeric,
+		PinType:        termii.PinTypeNumeric,
+		PinAttempts:    3,
+		PinTimeToLive:  5,
+		PinLength:      6,
+		PinPlaceholder: "< 1234 >",
+		MessageText:    "Your pin is < 1234 >",
+	})
+	assert.NoError(t, err)
+	pin, _ := srv.LastPin("2348109077743")
+	c.VerifyToken(termii.VerifyTokenRequest{PinID: token.PinID, Pin: "000000"})
+	c.VerifyToken(termii.VerifyTokenRequest{PinID: token.PinID, Pin: pin.Pin})
+	c.VerifyToken(termii.VerifyTokenRequest{PinID: token.PinID, Pin: pin.Pin})
+
+	balance, err := c.GetBalance()
+	assert.NoError(t, err)
+
+	srv.InjectError(termii.EndpointGetBalance, http.StatusServiceUnavailable, "Service unavailable", 2)
+	_, err = c.GetBalance()
+	assert.Error(t, err)
+
+	t.Run("Every request is reported", func(t *testing.T) {
+		assert.Len(t, inst.calls, 8)
+		assert.Len(t, inst.results, 8)
+		assert.Equal(t, termii.CallInfo{Method: http.MethodPost, Endpoint: termii.EndpointSendMessage}, inst.calls[0])
+		assert.Equal(t, http.StatusOK, inst.results[0].StatusCode)
+		assert.Equal(t, 1, inst.results[0].Attempts)
+		assert.NoError(t, inst.results[0].Err)
+	})
+
+	t.Run("Failed requests report their status, code and attempts", func(t *testing.T) {
+		last := inst.results[len(inst.results)-1]
+		assert.Equal(t, http.StatusServiceUnavailable, last.StatusCode)
+		assert.Equal(t, "503", last.Code)
+		assert.Equal(t, 2, last.Attempts)
+		assert.Error(t, last.Err)
+	})
+
+	t.Run("The context returned by StartCall reaches every attempt", func(t *testing.T) {
+		assert.Len(t, propagated, 9)
+		assert.Equal(t, termii.EndpointGetBalance, propagated[8])
+	})
+
+	t.Run("Messages are counted by channel", func(t *testing.T) {
+		assert.Equal(t, map[string]int{"generic": 3, "dnd": 1}, inst.sent)
+	})
+
+	t.Run("Wrong, correct and reused pins are reported", func(t *testing.T) {
+		assert.Equal(t, []bool{false, true, false}, inst.verified)
+	})
+
+	t.Run("Balances are reported", func(t *testing.T) {
+		assert.Equal(t, []float64{float64(balance.Balance)}, inst.balances)
+	})
+
+	t.Run("The api key is redacted from errors", func(t *testing.T) {
+		closed := termiitest.NewServer()
+		closed.Close()
+		inst := &recordingInstrumentation{sent: map[string]int{}}
+		_, err := closed.Client(termii.WithInstrumentation(inst)).GetBalance()
+		assert.Error(t, err)
+		assert.Len(t, inst.results, 1)
+		assert.Equal(t, 0, inst.results[0].StatusCode)
+		assert.Contains(t, inst.results[0].Err.Error(), closed.APIKey())
+		assert.Contains(t, inst.results[0].Error, "api_key=[REDACTED]")
+		assert.NotContains(t, inst.results[0].Error, closed.APIKey())
+	})
+}
@@ -0,0 +1,66 @@
+package gotermii
+
+import "context"
+
+// Messenger sends messages. It is satisfied by Client.
+type Messenger interface {
+	SendMessage(req SendMessageRequest) (SendMessageResponse, error)
+	SendMessageWithContext(ctx context.Context, req SendMessageRequest) (SendMessageResponse, error)
+	SendBulkMessage(req BulkMessageRequest) (BulkMessageResult, error)
+	SendBulkMessageWithContext(ctx context.Context, req BulkMessageRequest) (BulkMessageResult, error)
+	SendAutoGeneratedMessage(req AutoGeneratedMessageRequest) (AutoGeneratedMessageResponse, error)
+	SendAutoGeneratedMessageWithContext(ctx context.Context, req AutoGeneratedMessageRequest) (AutoGeneratedMessageResponse, error)
+	SetDeviceTemplate(req TemplateRequest) ([]TemplateResponse, error)
+	SetDeviceTemplateWithContext(ctx context.Context, req TemplateRequest) ([]TemplateResponse, error)
+}
+
+// TokenService issues and verifies one time pins. It is satisfied by Client.
+type TokenService interface {
+	SendToken(req SendTokenRequest) (SendTokenResponse, error)
+	SendTokenWithContext(ctx context.Context, req SendTokenRequest) (SendTokenResponse, error)
+	VerifyToken(req VerifyTokenRequest) (VerifyTokenResponse, error)
+	VerifyTokenWithContext(ctx context.Context, req VerifyTokenRequest) (VerifyTokenResponse, error)
+	GetInAppToken(req GenerateTokenRequest) (GenerateTokenResponse, error)
+	GetInAppTokenWithContext(ctx context.Context, req GenerateTokenRequest) (GenerateTokenResponse, error)
+	SendVoiceToken(req VoiceTokenRequest) (VoiceTokenResponse, error)
+	SendVoiceTokenWithContext(ctx context.Context, req VoiceTokenRequest) (VoiceTokenResponse, error)
+	SendVoiceCall(req VoiceCallRequest) (VoiceCallResponse, error)
+	SendVoiceCallWithContext(ctx context.Context, req VoiceCallRequest) (VoiceCallResponse, error)
+	SendEmailToken(req EmailTokenRequest) (EmailTokenResponse, error)
+	SendEmailTokenWithContext(ctx context.Context, req EmailTokenRequest) (EmailTokenResponse, error)
+}
+
+// InsightService reports on the account, phone numbers and sent messages. It is satisfied by Client.
+type InsightService interface {
+	GetBalance() (GetBalanceResponse, error)
+	GetBalanceWithContext(ctx context.Context) (GetBalanceResponse, error)
+	VerifyNumber(req VerifyNumberRequest) (VerifyNumberResponse, error)
+	VerifyNumberWithContext(ctx context.Context, req VerifyNumberRequest) (VerifyNumberResponse, error)
+	GetStatus(req StatusRequest) (StatusResponse, error)
+	GetStatusWithContext(ctx context.Context, req StatusRequest) (StatusResponse, error)
+	GetHistory() ([]HistoryResponse, error)
+	GetHistoryWithContext(ctx context.Context) ([]HistoryResponse, error)
+	GetMessageStatus(messageID string) (HistoryResponse, error)
+	GetMessageStatusWithContext(ctx context.Context, messageID string) (HistoryResponse, error)
+}
+
+// SenderIDService lists and registers sender ids. It is satisfied by Client.
+type SenderIDService interface {
+	FetchSenderID() (FetchSenderIdResponse, error)
+	FetchSenderIDWithContext(ctx context.Context) (FetchSenderIdResponse, error)
+	FetchSenderIDPage(page int) (FetchSenderIdResponse, error)
+	FetchSenderIDPageWithContext(ctx context.Context, page int) (FetchSenderIdResponse, error)
+	RegisterSender(req RegisterSenderIdRequest) (RegisterSenderResponse, error)
+	RegisterSenderWithContext(ctx context.Context, req RegisterSenderIdRequest) (RegisterSenderResponse, error)
+}
+
+// API groups the capabilities of the termii api. It is satisfied by Client and by the mock in the
+// termiimock package, so code depending on API can be tested without a termii server.
+type API interface {
+	Messenger
+	TokenService
+	InsightService
+	SenderIDService
+}
+
+var _ API = Client{}
@@ -0,0 +1,117 @@
+package gotermii
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultMaxPages is the number of pages an iterator fetches before giving up, unless configured otherwise
+const DefaultMaxPages = 100
+
+// ErrMaxPagesExceeded is returned by an iterator that stopped at its page limit while more pages were available
+var ErrMaxPagesExceeded = errors.New("iterator stopped at max pages limit")
+
+// SenderIDIterator iterates over every registered sender ID, fetching pages as they are needed
+// by following next_page_url.
+//
+//	it := client.IterateSenderIDs(0)
+//	for it.Next(ctx) {
+//		senderID := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type SenderIDIterator struct {
+	client   Client
+	maxPages int
+
+	nextPage int
+	fetched  int
+	buf      []FetchSenderIdData
+	cur      FetchSenderIdData
+	err      error
+}
+
+// IterateSenderIDs returns an iterator over every registered sender ID. At most maxPages pages
+// are fetched, DefaultMaxPages if maxPages is not positive.
+func (c Client) IterateSenderIDs(maxPages int) *SenderIDIterator {
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
+	}
+	return &SenderIDIterator{client: c, maxPages: maxPages, nextPage: 1}
+}
+
+// Next advances the iterator, fetching the next page if needed. It returns false when every
+// sender ID has been returned or an error occurred, in which case Err reports it.
+func (it *SenderIDIterator) Next(ctx context.Context) bool {
+	for len(it.buf) == 0 {
+		if it.err != nil || it.nextPage == 0 {
+			return false
+		}
+		if it.fetched >= it.maxPages {
+			it.err = errors.Wrapf(ErrMaxPagesExceeded, "fetched %d pages of sender ids", it.fetched)
+			return false
+		}
+
+		page, err := it.client.FetchSenderIDPageWithContext(ctx, it.nextPage)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.fetched++
+		it.buf = page.Data
+
+		next, err := nextPageNumber(page.NextPageURL)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		if next != 0 && next <= it.nextPage {
+			it.err = errors.Errorf("sender id page %d points back to page %d", it.nextPage, next)
+			return false
+		}
+		it.nextPage = next
+	}
+
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Value returns the sender ID the iterator is at
+func (it *SenderIDIterator) Value() FetchSenderIdData {
+	return it.cur
+}
+
+// Err returns the error that stopped the iterator, if any
+func (it *SenderIDIterator) Err() error {
+	return it.err
+}
+
+// CollectAll drains the iterator and returns every remaining sender ID. The sender IDs
+// collected before an error are returned along with it.
+func (it *SenderIDIterator) CollectAll(ctx context.Context) ([]FetchSenderIdData, error) {
+	var all []FetchSenderIdData
+	for it.Next(ctx) {
+		all = append(all, it.Value())
+	}
+	return all, it.Err()
+}
+
+// nextPageNumber returns the page number of a next_page_url, 0 if there is no next page
+func nextPageNumber(nextPageURL string) (int, error) {
+	if nextPageURL == "" {
+		return 0, nil
+	}
+	u, err := url.Parse(nextPageURL)
+	if err != nil {
+		return 0, errors.Wrap(err, "malformed next page url")
+	}
+	page, err := strconv.Atoi(u.Query().Get("page"))
+	if err != nil {
+		return 0, errors.Errorf("next page url %q has no page number", nextPageURL)
+	}
+	return page, nil
+}
@@ -0,0 +1,75 @@
+package gotermii_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	termii "github.com/Uchencho/go-termii"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pagedSenderIDs serves lastPage pages of two sender ids each
+func pagedSenderIDs(lastPage int, requestedPages *[]int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		page, err := strconv.Atoi(req.URL.Query().Get("page"))
+		if err != nil {
+			page = 1
+		}
+		*requestedPages = append(*requestedPages, page)
+
+		next := ""
+		if page < lastPage {
+			next = fmt.Sprintf("https://termii.com/api/sender-id?page=%d", page+1)
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"current_page":%d,"data":[{"sender_id":"Acme-%d-a"},{"sender_id":"Acme-%d-b"}],"last_page":%d,"next_page_url":%q}`,
+			page, page, page, lastPage, next)
+	}))
+}
+
+func TestSenderIDIterator(t *testing.T) {
+	var requestedPages []int
+	termiiService := pagedSenderIDs(3, &requestedPages)
+	defer termiiService.Close()
+
+	c, _ := termii.New(termii.WithAPIKey(termiiTestApiKey), termii.WithBaseURL(termiiService.URL))
+
+	var senderIDs []string
+	it := c.IterateSenderIDs(0)
+	for it.Next(context.Background()) {
+		senderIDs = append(senderIDs, it.Value().SenderID)
+	}
+
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, it.Err())
+	})
+
+	t.Run("Every page is fetched once", func(t *testing.T) {
+		assert.Equal(t, []int{1, 2, 3}, requestedPages)
+		assert.Equal(t, []string{"Acme-1-a", "Acme-1-b", "Acme-2-a", "Acme-2-b", "Acme-3-a", "Acme-3-b"}, senderIDs)
+	})
+}
+
+func TestSenderIDIteratorMaxPages(t *testing.T) {
+	var requestedPages []int
+	termiiService := pagedSenderIDs(5, &requestedPages)
+	defer termiiService.Close()
+
+	c, _ := termii.New(termii.WithAPIKey(termiiTestApiKey), termii.WithBaseURL(termiiService.URL))
+
+	all, err := c.IterateSenderIDs(2).CollectAll(context.Background())
+	t.Run("Max pages error is returned", func(t *testing.T) {
+		assert.True(t, errors.Is(err, termii.ErrMaxPagesExceeded))
+	})
+
+	t.Run("Sender ids collected before the limit are returned", func(t *testing.T) {
+		assert.Equal(t, []int{1, 2}, requestedPages)
+		assert.Len(t, all, 4)
+	})
+}
@@ -0,0 +1,268 @@
+package gotermii
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Logger is a structured logger taking a message followed by alternating keys and values.
+// It is satisfied by *slog.Logger.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// LogLevel is a representation of the verbosity of the logs written by the client
+type LogLevel int
+
+// Log levels, from the most to the least verbose
+const (
+	// LogLevelDebug logs the redacted body of every request and response
+	LogLevelDebug LogLevel = iota - 1
+	// LogLevelInfo logs the method, endpoint, status and latency of every attempt of a request
+	LogLevelInfo
+	// LogLevelWarn logs retries and throttled requests
+	LogLevelWarn
+	// LogLevelError logs failed requests
+	LogLevelError
+	// LogLevelOff disables logging
+	LogLevelOff
+)
+
+// redacted replaces secrets in logs
+const redacted = "[REDACTED]"
+
+// WithLogger logs requests made by the client to logger, at level and above. The api key is always
+// redacted from logs, phone numbers, email addresses and pins are redacted unless WithLogRedaction(false) is set.
+func WithLogger(logger Logger, level LogLevel) Option {
+	return func(c *Client) {
+		c.logger = logger
+		c.logLevel = level
+	}
+}
+
+// WithLogRedaction sets whether phone numbers and email addresses are masked, and pins redacted, in logs
+func WithLogRedaction(redactPII bool) Option {
+	return func(c *Client) {
+		c.logKeepPII = !redactPII
+	}
+}
+
+// requestLog is a representation of the logger of a client, it is disabled when logger is nil
+type requestLog struct {
+	logger  Logger
+	level   LogLevel
+	keepPII bool
+}
+
+// log returns the logger of the client. Without one, setting DEBUG_LOGS=true logs to the standard logger at debug level.
+func (s *Client) log() requestLog {
+	if s.logger != nil {
+		return requestLog{logger: s.logger, level: s.logLevel, keepPII: s.logKeepPII}
+	}
+	if os.Getenv("DEBUG_LOGS") == "true" {
+		return requestLog{logger: stdLogger{}, level: LogLevelDebug, keepPII: s.logKeepPII}
+	}
+	return requestLog{level: LogLevelOff}
+}
+
+func (l requestLog) enabled(level LogLevel) bool {
+	return l.logger != nil && level >= l.level && l.level < LogLevelOff
+}
+
+// attrs returns the attributes common to every log of a request
+func (l requestLog) attrs(ctx context.Context, method string, ep Endpoint, args ...interface{}) []interface{} {
+	attrs := []interface{}{"method", method, "endpoint", string(ep)}
+	if id, ok := RequestIDFromContext(ctx); ok {
+		attrs = append(attrs, "request_id", id)
+	}
+	return append(attrs, args...)
+}
+
+// attempt logs the outcome of a single attempt of a request
+func (l requestLog) attempt(ctx context.Context, method string, ep Endpoint, URL string, status int, latency time.Duration, err error) {
+	attrs := l.attrs(ctx, method, ep, "url", redactURL(URL, l.keepPII), "status", status, "latency", latency)
+	switch {
+	case err != nil && l.enabled(LogLevelError):
+		l.logger.Error("termii request failed", append(attrs, l.errorAttrs(err)...)...)
+	case err == nil && l.enabled(LogLevelInfo):
+		l.logger.Info("termii request", attrs...)
+	}
+}
+
+// body logs the redacted body of a request or response
+func (l requestLog) body(ctx context.Context, msg, method string, ep Endpoint, body []byte) {
+	if !l.enabled(LogLevelDebug) || body == nil {
+		return
+	}
+	l.logger.Debug(msg, l.attrs(ctx, method, ep, "body", redactBody(body, l.keepPII))...)
+}
+
+// retry logs a failed attempt that is going to be retried after wait
+func (l requestLog) retry(ctx context.Context, method string, ep Endpoint, attempt int, wait time.Duration, err error) {
+	if !l.enabled(LogLevelWarn) {
+		return
+	}
+	l.logger.Warn("retrying termii request", l.attrs(ctx, method, ep, append([]interface{}{"attempt", attempt, "wait", wait}, l.errorAttrs(err)...)...)...)
+}
+
+// throttled logs a request refused by the client side rate limiter, or given up on while waiting for it
+func (l requestLog) throttled(ctx context.Context, method string, ep Endpoint, err error) {
+	if !l.enabled(LogLevelWarn) {
+		return
+	}
+	l.logger.Warn("termii request throttled", l.attrs(ctx, method, ep, l.errorAttrs(err)...)...)
+}
+
+// errorAttrs describes err without the response body of an *APIError, which may carry personal data
+func (l requestLog) errorAttrs(err error) []interface{} {
+	if apiErr, ok := AsAPIError(err); ok {
+		return []interface{}{"termii_code", apiErr.Code, "error", apiErr.Message}
+	}
+	return []interface{}{"error", l.redactText(err.Error())}
+}
+
+// apiKeyParam matches the api key in a query string
+var apiKeyParam = regexp.MustCompile(`(api_key=)[^&\s"]*`)
+
+// redactText removes the api key from text such as an error message carrying a url
+func (l requestLog) redactText(text string) string {
+	return apiKeyParam.ReplaceAllString(text, "${1}"+redacted)
+}
+
+// piiFields are the fields holding phone numbers or email addresses, their values are masked
+var piiFields = map[string]bool{
+	"to": true, "phone_number": true, "phone_number_other": true, "msisdn": true, "number": true,
+	"receiver": true, "email_address": true,
+}
+
+// pinFields are the fields holding pins, their values are redacted
+var pinFields = map[string]bool{"pin": true, "otp": true}
+
+// redactURL redacts the api key, and unless keepPII phone numbers, in the query of rawURL
+func redactURL(rawURL string, keepPII bool) string {
+	base, query := rawURL, ""
+	if i := strings.Index(rawURL, "?"); i >= 0 {
+		base, query = rawURL[:i], rawURL[i+1:]
+	}
+	if query == "" {
+		return rawURL
+	}
+
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		kv := strings.SplitN(param, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		key, err := url.QueryUnescape(kv[0])
+		if err != nil {
+			continue
+		}
+		switch {
+		case isSecret(key):
+			params[i] = kv[0] + "=" + redacted
+		case !keepPII && piiFields[strings.ToLower(key)]:
+			value, _ := url.QueryUnescape(kv[1])
+			params[i] = kv[0] + "=" + url.QueryEscape(maskPII(value))
+		}
+	}
+	return base + "?" + strings.Join(params, "&")
+}
+
+// redactBody redacts the api key, and unless keepPII phone numbers, email addresses and pins, in a json body.
+// Bodies which are not json are omitted.
+func redactBody(body []byte, keepPII bool) string {
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Sprintf("[%d bytes omitted]", len(body))
+	}
+	bb, _ := json.Marshal(redactValue("", v, keepPII))
+	return string(bb)
+}
+
+func redactValue(key string, v interface{}, keepPII bool) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			val[k] = redactValue(strings.ToLower(k), child, keepPII)
+		}
+		return val
+	case []interface{}:
+		for i, child := range val {
+			val[i] = redactValue(key, child, keepPII)
+		}
+		return val
+	}
+
+	switch {
+	case isSecret(key):
+		return redacted
+	case keepPII:
+		return v
+	case pinFields[key] || (key == "code" && isNumeric(v)):
+		return redacted
+	case piiFields[key]:
+		if s, ok := v.(string); ok {
+			return maskPII(s)
+		}
+		return redacted
+	}
+	return v
+}
+
+func isSecret(key string) bool {
+	key = strings.ToLower(key)
+	return key == "api_key" || key == "secret_key"
+}
+
+// isNumeric reports whether v is a number or a string of digits, such as a voice or email code
+func isNumeric(v interface{}) bool {
+	switch val := v.(type) {
+	case json.Number:
+		return true
+	case string:
+		return val != "" && strings.Trim(val, "0123456789") == ""
+	}
+	return false
+}
+
+// maskPII masks all but the start and end of a phone number, or the first character of the local part of an email address
+func maskPII(s string) string {
+	if at := strings.LastIndex(s, "@"); at > 0 {
+		return s[:1] + strings.Repeat("*", at-1) + s[at:]
+	}
+	if len(s) <= 5 {
+		return strings.Repeat("*", len(s))
+	}
+	return s[:3] + strings.Repeat("*", len(s)-5) + s[len(s)-2:]
+}
+
+// stdLogger writes to the standard logger, it is used when DEBUG_LOGS=true and no logger is set
+type stdLogger struct{}
+
+func (stdLogger) Debug(msg string, args ...interface{}) { stdLog("DEBUG", msg, args) }
+func (stdLogger) Info(msg string, args ...interface{})  { stdLog("INFO", msg, args) }
+func (stdLogger) Warn(msg string, args ...interface{})  { stdLog("WARN", msg, args) }
+func (stdLogger) Error(msg string, args ...interface{}) { stdLog("ERROR", msg, args) }
+
+func stdLog(level, msg string, args []interface{}) {
+	var b strings.Builder
+	b.WriteString(level + " " + msg)
+	for i := 0; i+1 < len(args); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+	}
+	log.Print(b.String())
+}
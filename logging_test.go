@@ -0,0 +1,220 @@
+package gotermii_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	termii "github.com/Uchencho/go-termii"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingLogger is a representation of a Logger recording every entry
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+type logEntry struct {
+	level string
+	msg   string
+	attrs map[string]interface{}
+}
+
+func (l *recordingLogger) record(level, msg string, args []interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	attrs := make(map[string]interface{})
+	for i := 0; i+1 < len(args); i += 2 {
+		attrs[fmt.Sprint(args[i])] = args[i+1]
+	}
+	l.entries = append(l.entries, logEntry{level: level, msg: msg, attrs: attrs})
+}
+
+func (l *recordingLogger) Debug(msg string, args ...interface{}) { l.record("debug", msg, args) }
+func (l *recordingLogger) Info(msg string, args ...interface{})  { l.record("info", msg, args) }
+func (l *recordingLogger) Warn(msg string, args ...interface{})  { l.record("warn", msg, args) }
+func (l *recordingLogger) Error(msg string, args ...interface{}) { l.record("error", msg, args) }
+
+func (l *recordingLogger) levels() []string {
+	var levels []string
+	for _, e := range l.entries {
+		levels = append(levels, e.level)
+	}
+	return levels
+}
+
+func TestLogging(t *testing.T) {
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"pinId":"29ae67c2-c8e1-4165-8a51-8d3d7c298081","verified":"True","msisdn":"2348109077743"}`))
+	}))
+	defer termiiService.Close()
+
+	logger := &recordingLogger{}
+	c, _ := termii.New(termii.WithAPIKey("secret-api-key"), termii.WithBaseURL(termiiService.URL), termii.WithLogger(logger, termii.LogLevelDebug))
+
+	_, err := c.VerifyToken(termii.VerifyTokenRequest{PinID: "29ae67c2-c8e1-4165-8a51-8d3d7c298081", Pin: "195558"})
+	assert.NoError(t, err)
+
+	t.Run("Bodies and the request are logged", func(t *testing.T) {
+		assert.Equal(t, []string{"debug", "debug", "info"}, logger.levels())
+	})
+
+	t.Run("Request body is redacted", func(t *testing.T) {
+		body := logger.entries[0].attrs["body"].(string)
+		assert.NotContains(t, body, "secret-api-key")
+		assert.NotContains(t, body, "195558")
+		assert.Contains(t, body, "29ae67c2-c8e1-4165-8a51-8d3d7c298081")
+	})
+
+	t.Run("Response body is masked", func(t *testing.T) {
+		body := logger.entries[1].attrs["body"].(string)
+		assert.Contains(t, body, `"msisdn":"234********43"`)
+	})
+
+	t.Run("Request is described", func(t *testing.T) {
+		attrs := logger.entries[2].attrs
+		assert.Equal(t, http.MethodPost, attrs["method"])
+		assert.Equal(t, string(termii.EndpointVerifyToken), attrs["endpoint"])
+		assert.Equal(t, http.StatusOK, attrs["status"])
+		assert.IsType(t, time.Duration(0), attrs["latency"])
+		assert.Len(t, attrs["request_id"], 32)
+	})
+
+	logger.entries = nil
+	c.GetBalance()
+	t.Run("Api key is redacted from urls", func(t *testing.T) {
+		url := logger.entries[len(logger.entries)-1].attrs["url"].(string)
+		assert.NotContains(t, url, "secret-api-key")
+		assert.Contains(t, url, "api_key=[REDACTED]")
+	})
+
+	logger.entries = nil
+	c, _ = termii.New(termii.WithAPIKey("secret-api-key"), termii.WithBaseURL(termiiService.URL),
+		termii.WithLogger(logger, termii.LogLevelDebug), termii.WithLogRedaction(false))
+	c.VerifyToken(termii.VerifyTokenRequest{PinID: "29ae67c2-c8e1-4165-8a51-8d3d7c298081", Pin: "195558"})
+	t.Run("Personal data is kept when redaction is disabled", func(t *testing.T) {
+		body := logger.entries[0].attrs["body"].(string)
+		assert.Contains(t, body, "195558")
+		assert.NotContains(t, body, "secret-api-key")
+	})
+}
+
+func TestLoggingLevels(t *testing.T) {
+	var calls int
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"message":"Service unavailable for 2348109077743"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"user":"Acme","balance":100,"currency":"NGN"}`))
+	}))
+	defer termiiService.Close()
+
+	logger := &recordingLogger{}
+	c, _ := termii.New(
+		termii.WithAPIKey(termiiTestApiKey),
+		termii.WithBaseURL(termiiService.URL),
+		termii.WithRetryPolicy(termii.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}),
+		termii.WithLogger(logger, termii.LogLevelWarn),
+	)
+
+	_, err := c.GetBalance()
+	t.Run("Retries and failures are logged at warn level", func(t *testing.T) {
+		assert.Error(t, err)
+		assert.Equal(t, []string{"error", "warn", "error"}, logger.levels())
+		assert.Equal(t, 1, logger.entries[1].attrs["attempt"])
+	})
+
+	t.Run("Response body of failures is not logged", func(t *testing.T) {
+		for _, e := range logger.entries {
+			assert.NotContains(t, fmt.Sprint(e.attrs), "body=")
+		}
+	})
+
+	logger.entries = nil
+	_, err = c.GetBalance()
+	t.Run("Successful requests are not logged at warn level", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Empty(t, logger.entries)
+	})
+}
+
+func TestLoggingThrottled(t *testing.T) {
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"user":"Acme","balance":100,"currency":"NGN"}`))
+	}))
+	defer termiiService.Close()
+
+	t.Run("Requests refused by the rate limiter are logged at warn level", func(t *testing.T) {
+		logger := &recordingLogger{}
+		c, _ := termii.New(
+			termii.WithAPIKey(termiiTestApiKey),
+			termii.WithBaseURL(termiiService.URL),
+			termii.WithRateLimit(termii.RateLimitConfig{Global: termii.Limit{Rate: 0.001, Burst: 1}, FailFast: true}),
+			termii.WithLogger(logger, termii.LogLevelWarn),
+		)
+
+		c.GetBalance()
+		_, err := c.GetBalance()
+		assert.True(t, termii.IsRateLimited(err))
+		assert.Equal(t, []string{"warn"}, logger.levels())
+		assert.Equal(t, "termii request throttled", logger.entries[0].msg)
+		assert.Equal(t, string(termii.EndpointGetBalance), logger.entries[0].attrs["endpoint"])
+		assert.Equal(t, termii.ErrRateLimited.Error(), logger.entries[0].attrs["error"])
+	})
+
+	t.Run("Requests given up on while waiting for the rate limiter are logged at warn level", func(t *testing.T) {
+		logger := &recordingLogger{}
+		c, _ := termii.New(
+			termii.WithAPIKey(termiiTestApiKey),
+			termii.WithBaseURL(termiiService.URL),
+			termii.WithRateLimit(termii.RateLimitConfig{Global: termii.Limit{Rate: 0.001, Burst: 1}}),
+			termii.WithLogger(logger, termii.LogLevelWarn),
+		)
+
+		c.GetBalance()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := c.GetBalanceWithContext(ctx)
+		assert.Error(t, err)
+		assert.Equal(t, []string{"warn"}, logger.levels())
+		assert.Equal(t, "termii request throttled", logger.entries[0].msg)
+	})
+}
+
+func TestDebugLogsEnv(t *testing.T) {
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"user":"Acme","balance":100,"currency":"NGN"}`))
+	}))
+	defer termiiService.Close()
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	os.Setenv("DEBUG_LOGS", "true")
+	defer os.Unsetenv("DEBUG_LOGS")
+
+	c, _ := termii.New(termii.WithAPIKey("secret-api-key"), termii.WithBaseURL(termiiService.URL))
+	c.GetBalance()
+
+	t.Run("DEBUG_LOGS logs redacted requests to the standard logger", func(t *testing.T) {
+		assert.True(t, strings.Contains(buf.String(), "DEBUG termii response body"))
+		assert.NotContains(t, buf.String(), "secret-api-key")
+	})
+}
@@ -0,0 +1,104 @@
+package gotermii
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// Doer executes an http request, it is satisfied by *http.Client
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is an adapter allowing a function to be used as a Doer
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req)
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware decorates the Doer executing requests. Every attempt of a request is passed through
+// the middlewares, with a request of its own which middlewares are free to modify.
+type Middleware func(next Doer) Doer
+
+// WithMiddleware adds middlewares around the http client executing requests. Middlewares are
+// called in the order they were added, the first one sees the request first.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) {
+		c.middleware = append(append([]Middleware(nil), c.middleware...), mw...)
+	}
+}
+
+// doer returns the http client wrapped in the middlewares of the client
+func (s *Client) doer() Doer {
+	var d Doer = s.client
+	for i := len(s.middleware) - 1; i >= 0; i-- {
+		d = s.middleware[i](d)
+	}
+	return d
+}
+
+// DefaultRequestIDHeader is the header RequestID sets when none is given
+const DefaultRequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying id, which RequestID sends along with requests made with it
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request id carried by ctx, if any
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// RequestID sets header, DefaultRequestIDHeader if empty, to the request id carried by the context of
+// the request. The client gives requests made without one a random id, so every attempt of a request shares its id.
+func RequestID(header string) Middleware {
+	if header == "" {
+		header = DefaultRequestIDHeader
+	}
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if id, ok := RequestIDFromContext(req.Context()); ok && req.Header.Get(header) == "" {
+				req.Header.Set(header, id)
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Headers sets headers on every request, replacing any value already set
+func Headers(headers http.Header) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			for k, v := range headers {
+				req.Header[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+// UserAgent appends product, e.g acme-notifier/1.0, to the User-Agent header of every request
+func UserAgent(product string) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			ua := strings.TrimSpace(req.Header.Get("User-Agent") + " " + product)
+			req.Header.Set("User-Agent", ua)
+			return next.Do(req)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package gotermii_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	termii "github.com/Uchencho/go-termii"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	var received []*http.Request
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		received = append(received, req)
+		if len(received)%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"user":"Acme","balance":100,"currency":"NGN"}`))
+	}))
+	defer termiiService.Close()
+
+	var order []string
+	trace := func(name string) termii.Middleware {
+		return func(next termii.Doer) termii.Doer {
+			return termii.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.Do(req)
+			})
+		}
+	}
+
+	c, _ := termii.New(
+		termii.WithAPIKey(termiiTestApiKey),
+		termii.WithBaseURL(termiiService.URL),
+		termii.WithUserAgent("go-termii"),
+		termii.WithRetryPolicy(termii.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}),
+		termii.WithMiddleware(trace("outer"), termii.RequestID("")),
+		termii.WithMiddleware(
+			termii.Headers(http.Header{"X-Proxy-Authorization": {"Bearer proxy-token"}}),
+			termii.UserAgent("acme-notifier/1.0"),
+			trace("inner"),
+		),
+	)
+
+	ctx := termii.ContextWithRequestID(context.Background(), "req-123")
+	_, err := c.GetBalanceWithContext(ctx)
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Middlewares wrap every attempt in order", func(t *testing.T) {
+		assert.Equal(t, []string{"outer", "inner", "outer", "inner"}, order)
+	})
+
+	t.Run("Request id is propagated from the context", func(t *testing.T) {
+		for _, req := range received {
+			assert.Equal(t, "req-123", req.Header.Get(termii.DefaultRequestIDHeader))
+		}
+	})
+
+	t.Run("Custom headers and user agent are sent", func(t *testing.T) {
+		assert.Equal(t, "Bearer proxy-token", received[1].Header.Get("X-Proxy-Authorization"))
+		assert.Equal(t, "go-termii acme-notifier/1.0", received[1].UserAgent())
+	})
+
+	received = nil
+	_, err = c.GetBalance()
+	t.Run("Request id is generated once when the context has none", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Len(t, received, 2)
+		first := received[0].Header.Get(termii.DefaultRequestIDHeader)
+		assert.Len(t, first, 32)
+		assert.Equal(t, first, received[1].Header.Get(termii.DefaultRequestIDHeader))
+	})
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	cached := func(next termii.Doer) termii.Doer {
+		return termii.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			rec := httptest.NewRecorder()
+			rec.WriteString(`{"user":"Cache","balance":1,"currency":"NGN"}`)
+			return rec.Result(), nil
+		})
+	}
+
+	c, _ := termii.New(termii.WithAPIKey(termiiTestApiKey), termii.WithBaseURL("http://termii.invalid"), termii.WithMiddleware(cached))
+	resp, err := c.GetBalance()
+	t.Run("Middleware can answer without calling termii", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Equal(t, "Cache", resp.User)
+	})
+}
@@ -0,0 +1,161 @@
+package gotermii
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultBalancePollInterval is the interval a BalanceMonitor polls at when none is configured
+const DefaultBalancePollInterval = 5 * time.Minute
+
+// BalanceThreshold is a representation of a balance level to be notified about.
+// OnLow is called when the balance drops below Level, and is not called again until the balance
+// has recovered to at least Level + Hysteresis, at which point OnRecovered is called.
+type BalanceThreshold struct {
+	Level       int
+	Hysteresis  int
+	OnLow       func(BalanceEvent)
+	OnRecovered func(BalanceEvent)
+}
+
+// BalanceEvent is a representation of a balance crossing a threshold
+type BalanceEvent struct {
+	Threshold BalanceThreshold
+	Balance   GetBalanceResponse
+	CheckedAt time.Time
+}
+
+// BalanceMonitorConfig is a representation of the configuration of a BalanceMonitor
+type BalanceMonitorConfig struct {
+	// Interval between balance checks, DefaultBalancePollInterval is used when it is not positive
+	Interval   time.Duration
+	Thresholds []BalanceThreshold
+	// OnError is called with the error of every failed balance check
+	OnError func(error)
+}
+
+// BalanceMonitor polls the balance of a termii wallet and notifies thresholds it crosses.
+// Checks, and so callbacks, run one at a time whether they are made by Run or by calling Check.
+type BalanceMonitor struct {
+	client Client
+	cfg    BalanceMonitorConfig
+
+	// checks serialises checks, so balances are recorded and callbacks called in the order they were fetched
+	checks sync.Mutex
+
+	mu        sync.RWMutex
+	running   bool
+	last      GetBalanceResponse
+	checkedAt time.Time
+	// low reports, per threshold, whether OnLow has been called since the balance last recovered
+	low []bool
+}
+
+// NewBalanceMonitor creates a monitor of the balance of the wallet of c, it starts polling once Run is called
+func NewBalanceMonitor(c Client, cfg BalanceMonitorConfig) *BalanceMonitor {
+	if cfg.Interval <= 0 {
+		cfg.Interval = DefaultBalancePollInterval
+	}
+	return &BalanceMonitor{client: c, cfg: cfg, low: make([]bool, len(cfg.Thresholds))}
+}
+
+// Run checks the balance immediately and then on every interval until ctx is cancelled, at which
+// point it returns the error of ctx. A monitor can only be run once at a time.
+func (m *BalanceMonitor) Run(ctx context.Context) error {
+	m.mu.Lock()
+	if m.running {
+		m.mu.Unlock()
+		return errors.New("balance monitor - already running")
+	}
+	m.running = true
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		m.running = false
+		m.mu.Unlock()
+	}()
+
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		m.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check fetches the balance once and notifies the thresholds it crossed. It is called by Run,
+// and may be called directly to check the balance outside of the interval, in which case it waits
+// for any check in progress. Callbacks must not call Check.
+func (m *BalanceMonitor) Check(ctx context.Context) {
+	m.checks.Lock()
+	defer m.checks.Unlock()
+
+	balance, err := m.client.GetBalanceWithContext(ctx)
+	if err != nil {
+		// a check interrupted by the monitor being stopped is not a failure
+		if ctx.Err() == nil && m.cfg.OnError != nil {
+			m.cfg.OnError(errors.Wrap(err, "balance monitor - unable to get balance"))
+		}
+		return
+	}
+
+	m.mu.Lock()
+	m.last, m.checkedAt = balance, time.Now()
+	var events []func()
+	for i, threshold := range m.cfg.Thresholds {
+		event := BalanceEvent{Threshold: threshold, Balance: balance, CheckedAt: m.checkedAt}
+		switch {
+		case !m.low[i] && balance.Balance < threshold.Level:
+			m.low[i] = true
+			events = append(events, notify(threshold.OnLow, event))
+		case m.low[i] && balance.Balance >= threshold.Level+threshold.Hysteresis:
+			m.low[i] = false
+			events = append(events, notify(threshold.OnRecovered, event))
+		}
+	}
+	m.mu.Unlock()
+
+	// callbacks are called without holding the lock so they can read the monitor
+	for _, event := range events {
+		event()
+	}
+}
+
+func notify(callback func(BalanceEvent), event BalanceEvent) func() {
+	return func() {
+		if callback != nil {
+			callback(event)
+		}
+	}
+}
+
+// Last returns the last known balance and the time it was checked, ok is false until a check has succeeded
+func (m *BalanceMonitor) Last() (balance GetBalanceResponse, checkedAt time.Time, ok bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.last, m.checkedAt, !m.checkedAt.IsZero()
+}
+
+// Balance returns the last known balance
+func (m *BalanceMonitor) Balance() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.last.Balance
+}
+
+// Currency returns the currency of the last known balance
+func (m *BalanceMonitor) Currency() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.last.Currency
+}
@@ -0,0 +1,171 @@
+package gotermii_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	termii "github.com/Uchencho/go-termii"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func balanceService(balances ...int) *httptest.Server {
+	var (
+		mu    sync.Mutex
+		calls int
+	)
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		balance := balances[len(balances)-1]
+		if calls < len(balances) {
+			balance = balances[calls]
+		}
+		calls++
+		mu.Unlock()
+
+		if balance < 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"user":"Acme","balance":%d,"currency":"NGN"}`, balance)
+	}))
+}
+
+func TestBalanceMonitorThresholds(t *testing.T) {
+	termiiService := balanceService(100, 40, 45, 55, -1, 61, 30)
+	defer termiiService.Close()
+
+	c, _ := termii.New(termii.WithAPIKey(termiiTestApiKey), termii.WithBaseURL(termiiService.URL))
+
+	var events []string
+	var errs []error
+	m := termii.NewBalanceMonitor(c, termii.BalanceMonitorConfig{
+		Thresholds: []termii.BalanceThreshold{{
+			Level:       50,
+			Hysteresis:  10,
+			OnLow:       func(e termii.BalanceEvent) { events = append(events, fmt.Sprintf("low %d", e.Balance.Balance)) },
+			OnRecovered: func(e termii.BalanceEvent) { events = append(events, fmt.Sprintf("recovered %d", e.Balance.Balance)) },
+		}},
+		OnError: func(err error) { errs = append(errs, err) },
+	})
+
+	_, _, ok := m.Last()
+	t.Run("No balance is known before the first check", func(t *testing.T) {
+		assert.False(t, ok)
+	})
+
+	for i := 0; i < 7; i++ {
+		m.Check(context.Background())
+	}
+
+	t.Run("Thresholds fire once per crossing", func(t *testing.T) {
+		assert.Equal(t, []string{"low 40", "recovered 61", "low 30"}, events)
+	})
+
+	t.Run("Failed checks are reported", func(t *testing.T) {
+		assert.Len(t, errs, 1)
+	})
+
+	balance, checkedAt, ok := m.Last()
+	t.Run("Last balance is exposed", func(t *testing.T) {
+		assert.True(t, ok)
+		assert.False(t, checkedAt.IsZero())
+		assert.Equal(t, termii.GetBalanceResponse{User: "Acme", Balance: 30, Currency: "NGN"}, balance)
+		assert.Equal(t, 30, m.Balance())
+		assert.Equal(t, "NGN", m.Currency())
+	})
+}
+
+func TestBalanceMonitorRun(t *testing.T) {
+	termiiService := balanceService(100, 80, 20)
+	defer termiiService.Close()
+
+	c, _ := termii.New(termii.WithAPIKey(termiiTestApiKey), termii.WithBaseURL(termiiService.URL))
+
+	low := make(chan termii.BalanceEvent, 1)
+	m := termii.NewBalanceMonitor(c, termii.BalanceMonitorConfig{
+		Interval:   10 * time.Millisecond,
+		Thresholds: []termii.BalanceThreshold{{Level: 50, OnLow: func(e termii.BalanceEvent) { low <- e }}},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- m.Run(ctx) }()
+
+	select {
+	case e := <-low:
+		t.Run("Balance is polled until it drops below the threshold", func(t *testing.T) {
+			assert.Equal(t, 20, e.Balance.Balance)
+		})
+	case <-time.After(5 * time.Second):
+		t.Fatal("threshold was not crossed")
+	}
+
+	t.Run("Monitor can not run twice at once", func(t *testing.T) {
+		assert.Error(t, m.Run(ctx))
+	})
+
+	cancel()
+	select {
+	case err := <-done:
+		t.Run("Monitor stops on cancellation", func(t *testing.T) {
+			assert.Equal(t, context.Canceled, err)
+		})
+	case <-time.After(5 * time.Second):
+		t.Fatal("monitor did not stop")
+	}
+}
+
+func TestBalanceMonitorConcurrentChecks(t *testing.T) {
+	var (
+		mu                   sync.Mutex
+		calls, inFlight, max int
+	)
+	started := make(chan struct{}, 2)
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		calls++
+		balance := calls
+		inFlight++
+		if inFlight > max {
+			max = inFlight
+		}
+		mu.Unlock()
+		started <- struct{}{}
+
+		// the first check is answered last unless checks are serialised
+		if balance == 1 {
+			time.Sleep(50 * time.Millisecond)
+		}
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"user":"Acme","balance":%d,"currency":"NGN"}`, balance)
+	}))
+	defer termiiService.Close()
+
+	c, _ := termii.New(termii.WithAPIKey(termiiTestApiKey), termii.WithBaseURL(termiiService.URL))
+	m := termii.NewBalanceMonitor(c, termii.BalanceMonitorConfig{})
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); m.Check(context.Background()) }()
+	<-started
+	go func() { defer wg.Done(); m.Check(context.Background()) }()
+	wg.Wait()
+
+	t.Run("Checks run one at a time", func(t *testing.T) {
+		assert.Equal(t, 1, max)
+	})
+
+	t.Run("The latest balance is kept", func(t *testing.T) {
+		assert.Equal(t, 2, m.Balance())
+	})
+}
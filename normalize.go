@@ -0,0 +1,48 @@
+package gotermii
+
+import (
+	"strings"
+
+	"github.com/Uchencho/go-termii/phone"
+	"github.com/pkg/errors"
+)
+
+// WithPhoneNormalization normalizes the phone numbers of SendMessageRequest, SendTokenRequest,
+// VerifyNumberRequest and StatusRequest into termii's format before they are sent. Numbers in national
+// format are assumed to be in defaultCountry, an ISO code such as NG or a dialing code such as 234,
+// unless the request carries its own country code. A number that can not be normalized is rejected
+// with a *ValidationError.
+func WithPhoneNormalization(defaultCountry string) Option {
+	return func(c *Client) {
+		c.normalizePhones = true
+		c.phoneCountry = strings.TrimSpace(defaultCountry)
+	}
+}
+
+func (c Client) validatePhoneNormalization() error {
+	if !c.normalizePhones || c.phoneCountry == "" {
+		return nil
+	}
+	if _, ok := phone.LookupCountry(c.phoneCountry); !ok {
+		return errors.Errorf("config - unknown default phone country %q", c.phoneCountry)
+	}
+	return nil
+}
+
+// normalizePhone rewrites number in place when phone normalization is enabled. Empty numbers are
+// left for the validation of the request to report.
+func (c Client) normalizePhone(request, field string, number *string, country string) error {
+	if !c.normalizePhones || strings.TrimSpace(*number) == "" {
+		return nil
+	}
+	if country == "" {
+		country = c.phoneCountry
+	}
+
+	normalized, err := phone.Normalize(*number, country)
+	if err != nil {
+		return &ValidationError{Request: request, Fields: []FieldError{{Field: field, Message: err.Error()}}}
+	}
+	*number = normalized
+	return nil
+}
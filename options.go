@@ -0,0 +1,118 @@
+package gotermii
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// Option is a representation of a functional option used to configure a Client
+type Option func(*Client)
+
+// WithAPIKey sets the api key sent along with every request
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) {
+		c.config.APIKey = apiKey
+	}
+}
+
+// WithBaseURL sets the base url of the termii api, e.g https://api.ng.termii.com
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.config.BaseURL = baseURL
+	}
+}
+
+// WithSenderID sets the sender id used when registering a sender
+func WithSenderID(senderID string) Option {
+	return func(c *Client) {
+		c.config.SenderID = senderID
+	}
+}
+
+// WithConfig sets the api key, base url and sender id from a Config
+func WithConfig(config Config) Option {
+	return func(c *Client) {
+		c.config = config
+	}
+}
+
+// WithHTTPClient sets the http client used to execute requests
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		c.client = client
+	}
+}
+
+// WithTimeout sets the timeout of the http client used to execute requests.
+// A custom http client passed through WithHTTPClient is copied rather than modified.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent along with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// New creates a termii client configured by opts. An error is returned if the
+// resulting configuration has no api key or a malformed base url.
+func New(opts ...Option) (Client, error) {
+	var c Client
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	if err := c.config.validate(); err != nil {
+		return Client{}, err
+	}
+	if err := c.validatePhoneNormalization(); err != nil {
+		return Client{}, err
+	}
+	c.config.BaseURL = strings.TrimSuffix(c.config.BaseURL, "/")
+
+	switch {
+	case c.client == nil:
+		timeout := defaultTimeout
+		if c.timeout > 0 {
+			timeout = c.timeout
+		}
+		c.client = &http.Client{Timeout: timeout}
+	case c.timeout > 0:
+		hc := *c.client
+		hc.Timeout = c.timeout
+		c.client = &hc
+	}
+	return c, nil
+}
+
+// NewClientFromConfig creates a termii client from config, validating it in the same way as New
+func NewClientFromConfig(config Config, opts ...Option) (Client, error) {
+	return New(append([]Option{WithConfig(config)}, opts...)...)
+}
+
+func (cfg Config) validate() error {
+	if strings.TrimSpace(cfg.APIKey) == "" {
+		return errors.New("config - api key is required")
+	}
+	if cfg.BaseURL == "" {
+		return errors.New("config - base url is required")
+	}
+	u, err := url.Parse(cfg.BaseURL)
+	if err != nil {
+		return errors.Wrap(err, "config - malformed base url")
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Errorf("config - malformed base url %q, expected an absolute http(s) url", cfg.BaseURL)
+	}
+	return nil
+}
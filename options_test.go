@@ -0,0 +1,141 @@
+package gotermii_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	termii "github.com/Uchencho/go-termii"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewValidation(t *testing.T) {
+	table := []struct {
+		name string
+		opts []termii.Option
+	}{
+		{
+			name: "Missing api key",
+			opts: []termii.Option{termii.WithBaseURL("https://api.ng.termii.com")},
+		},
+		{
+			name: "Missing base url",
+			opts: []termii.Option{termii.WithAPIKey(termiiTestApiKey)},
+		},
+		{
+			name: "Base url without scheme",
+			opts: []termii.Option{termii.WithAPIKey(termiiTestApiKey), termii.WithBaseURL("api.ng.termii.com")},
+		},
+		{
+			name: "Base url with invalid characters",
+			opts: []termii.Option{termii.WithAPIKey(termiiTestApiKey), termii.WithBaseURL("https://api.ng.termii.com/%zz")},
+		},
+	}
+
+	for _, entry := range table {
+		_, err := termii.New(entry.opts...)
+		t.Run(fmt.Sprintf("%s - Error is returned", entry.name), func(t *testing.T) {
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := termii.NewClientFromConfig(termii.Config{APIKey: termiiTestApiKey, BaseURL: "https://api.ng.termii.com/"})
+	t.Run("Valid config - No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+}
+
+func TestNewWithOptions(t *testing.T) {
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		t.Run("URL and headers are as expected", func(t *testing.T) {
+			expectedURL := fmt.Sprintf("/api/get-balance?api_key=%s", "tenant-key")
+			assert.Equal(t, expectedURL, req.RequestURI)
+			assert.Equal(t, "acme-notifier/1.0", req.Header.Get("User-Agent"))
+		})
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"user":"Acme","balance":100,"currency":"NGN"}`))
+	}))
+	defer termiiService.Close()
+
+	c, err := termii.New(
+		termii.WithAPIKey("tenant-key"),
+		termii.WithBaseURL(termiiService.URL+"/"),
+		termii.WithHTTPClient(&http.Client{}),
+		termii.WithTimeout(5*time.Second),
+		termii.WithUserAgent("acme-notifier/1.0"),
+	)
+	t.Run("No error is returned on creation", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	resp, err := c.GetBalance()
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Response is as expected", func(t *testing.T) {
+		assert.Equal(t, termii.GetBalanceResponse{User: "Acme", Balance: 100, Currency: "NGN"}, resp)
+	})
+}
+
+func TestPhoneNormalization(t *testing.T) {
+	var numbers []string
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body struct {
+			To          string `json:"to"`
+			PhoneNumber string `json:"phone_number"`
+		}
+		json.NewDecoder(req.Body).Decode(&body)
+		numbers = append(numbers, body.To+body.PhoneNumber)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer termiiService.Close()
+
+	c, err := termii.New(
+		termii.WithAPIKey(termiiTestApiKey),
+		termii.WithBaseURL(termiiService.URL),
+		termii.WithPhoneNormalization("NG"),
+	)
+	t.Run("No error is returned on creation", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	_, err = c.SendMessage(termii.SendMessageRequest{
+		To: "0803 123 4567", From: "Acme", Sms: "Hello", Type: termii.SMSTypePlain, Channel: termii.ChannelGeneric,
+	})
+	assert.NoError(t, err)
+	_, err = c.VerifyNumber(termii.VerifyNumberRequest{PhoneNumber: "+234 803 123 4567"})
+	assert.NoError(t, err)
+	_, err = c.GetStatus(termii.StatusRequest{PhoneNumber: "024 123 4567", CountryCode: "GH"})
+	assert.NoError(t, err)
+
+	t.Run("Numbers are sent in termii's format", func(t *testing.T) {
+		assert.Equal(t, []string{"2348031234567", "2348031234567", "233241234567"}, numbers)
+	})
+
+	_, err = c.SendMessage(termii.SendMessageRequest{
+		To: "0803 123", From: "Acme", Sms: "Hello", Type: termii.SMSTypePlain, Channel: termii.ChannelGeneric,
+	})
+	t.Run("Invalid number is rejected before it is sent", func(t *testing.T) {
+		validationErr, ok := termii.AsValidationError(err)
+		assert.True(t, ok)
+		assert.Equal(t, "to", validationErr.Fields[0].Field)
+		assert.Len(t, numbers, 3)
+	})
+
+	_, err = termii.New(
+		termii.WithAPIKey(termiiTestApiKey),
+		termii.WithBaseURL(termiiService.URL),
+		termii.WithPhoneNormalization("Narnia"),
+	)
+	t.Run("Unknown default country is rejected on creation", func(t *testing.T) {
+		assert.Error(t, err)
+	})
+}
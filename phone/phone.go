@@ -0,0 +1,210 @@
+// Package phone normalizes phone numbers into the international format termii expects,
+// the country dialing code followed by the national number without a leading '+'.
+package phone
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Errors returned when a number can not be normalized
+var (
+	ErrInvalidNumber  = errors.New("invalid phone number")
+	ErrUnknownCountry = errors.New("unknown country")
+)
+
+// Country is a representation of a market termii delivers to
+type Country struct {
+	Name string
+	// ISO is the ISO 3166-1 alpha-2 code of the country, e.g NG
+	ISO string
+	// DialCode is the country calling code without '+', e.g 234
+	DialCode string
+	// NationalLengths are the allowed lengths of a national number, without the trunk prefix
+	NationalLengths []int
+}
+
+// Countries are the markets whose numbers can be normalized from national format and detected by prefix
+var Countries = []Country{
+	{Name: "Nigeria", ISO: "NG", DialCode: "234", NationalLengths: []int{10}},
+	{Name: "Ghana", ISO: "GH", DialCode: "233", NationalLengths: []int{9}},
+	{Name: "Kenya", ISO: "KE", DialCode: "254", NationalLengths: []int{9}},
+	{Name: "South Africa", ISO: "ZA", DialCode: "27", NationalLengths: []int{9}},
+	{Name: "Uganda", ISO: "UG", DialCode: "256", NationalLengths: []int{9}},
+	{Name: "Tanzania", ISO: "TZ", DialCode: "255", NationalLengths: []int{9}},
+	{Name: "Rwanda", ISO: "RW", DialCode: "250", NationalLengths: []int{9}},
+	{Name: "Zambia", ISO: "ZM", DialCode: "260", NationalLengths: []int{9}},
+	{Name: "Malawi", ISO: "MW", DialCode: "265", NationalLengths: []int{9}},
+	{Name: "Ethiopia", ISO: "ET", DialCode: "251", NationalLengths: []int{9}},
+	{Name: "Egypt", ISO: "EG", DialCode: "20", NationalLengths: []int{10}},
+	{Name: "Cameroon", ISO: "CM", DialCode: "237", NationalLengths: []int{9}},
+	{Name: "Cote d'Ivoire", ISO: "CI", DialCode: "225", NationalLengths: []int{10}},
+	{Name: "Senegal", ISO: "SN", DialCode: "221", NationalLengths: []int{9}},
+	{Name: "Benin", ISO: "BJ", DialCode: "229", NationalLengths: []int{8, 10}},
+	{Name: "Togo", ISO: "TG", DialCode: "228", NationalLengths: []int{8}},
+	{Name: "Burkina Faso", ISO: "BF", DialCode: "226", NationalLengths: []int{8}},
+	{Name: "Sierra Leone", ISO: "SL", DialCode: "232", NationalLengths: []int{8}},
+	{Name: "Liberia", ISO: "LR", DialCode: "231", NationalLengths: []int{7, 8, 9}},
+	{Name: "Gambia", ISO: "GM", DialCode: "220", NationalLengths: []int{7}},
+	{Name: "United Kingdom", ISO: "GB", DialCode: "44", NationalLengths: []int{10}},
+	{Name: "United States", ISO: "US", DialCode: "1", NationalLengths: []int{10}},
+}
+
+// Bounds of an E.164 number, excluding the leading '+'
+const (
+	minE164Digits = 8
+	maxE164Digits = 15
+)
+
+// LookupCountry returns the country with the ISO code, or dialing code, code
+func LookupCountry(code string) (Country, bool) {
+	code = strings.TrimPrefix(strings.TrimSpace(code), "+")
+	for _, c := range Countries {
+		if strings.EqualFold(c.ISO, code) || c.DialCode == code {
+			return c, true
+		}
+	}
+	return Country{}, false
+}
+
+// DetectCountry returns the country of a number in international format, with or without a
+// leading '+'. The longest matching dialing code wins.
+func DetectCountry(number string) (Country, bool) {
+	digits := strings.TrimPrefix(number, "+")
+	var match Country
+	for _, c := range Countries {
+		if strings.HasPrefix(digits, c.DialCode) && len(c.DialCode) > len(match.DialCode) {
+			match = c
+		}
+	}
+	return match, match.DialCode != ""
+}
+
+// Normalize converts raw into termii's format, e.g 0803 123 4567, +234 803 123 4567 and
+// 2348031234567 all become 2348031234567.
+//
+// Numbers in national format, starting with a trunk '0' or without a dialing code, are assumed
+// to be in defaultCountry, given as an ISO code (NG) or a dialing code (234). defaultCountry may
+// be empty when raw is known to be in international format.
+func Normalize(raw, defaultCountry string) (string, error) {
+	cleaned, international, err := clean(raw)
+	if err != nil {
+		return "", err
+	}
+
+	var number string
+	switch {
+	case international:
+		number = cleaned
+	case strings.HasPrefix(cleaned, "0"):
+		country, err := defaultCountryOf(raw, defaultCountry)
+		if err != nil {
+			return "", err
+		}
+		number = country.DialCode + strings.TrimLeft(cleaned, "0")
+	default:
+		// bare digits are international unless they have the length of a national number of the default country
+		number = cleaned
+		if country, ok := LookupCountry(defaultCountry); ok && hasLength(country, len(cleaned)) && !validFor(country, cleaned) {
+			number = country.DialCode + cleaned
+		}
+	}
+
+	if err := Validate(number); err != nil {
+		return "", err
+	}
+	return number, nil
+}
+
+// E164 normalizes raw like Normalize and returns it with a leading '+'
+func E164(raw, defaultCountry string) (string, error) {
+	number, err := Normalize(raw, defaultCountry)
+	if err != nil {
+		return "", err
+	}
+	return "+" + number, nil
+}
+
+// Validate checks that number, with or without a leading '+', is a valid E.164 number. Numbers
+// of a known country must also have one of its national number lengths.
+func Validate(number string) error {
+	digits := strings.TrimPrefix(number, "+")
+	if len(digits) < minE164Digits || len(digits) > maxE164Digits {
+		return errors.Wrapf(ErrInvalidNumber, "%q must have between %d and %d digits", number, minE164Digits, maxE164Digits)
+	}
+	if !isDigits(digits) || digits[0] == '0' {
+		return errors.Wrapf(ErrInvalidNumber, "%q is not in international format", number)
+	}
+	if country, ok := DetectCountry(digits); ok && !validFor(country, digits) {
+		return errors.Wrapf(ErrInvalidNumber, "%q is not a valid %s number", number, country.Name)
+	}
+	return nil
+}
+
+// IsE164 reports whether number is a '+' followed by a valid E.164 number
+func IsE164(number string) bool {
+	return strings.HasPrefix(number, "+") && Validate(number) == nil
+}
+
+// clean strips formatting from raw and reports whether it carried an international prefix
+func clean(raw string) (string, bool, error) {
+	s := strings.TrimSpace(raw)
+	if s == "" {
+		return "", false, errors.Wrap(ErrInvalidNumber, "phone number is empty")
+	}
+
+	international := false
+	switch {
+	case strings.HasPrefix(s, "+"):
+		international, s = true, s[1:]
+	case strings.HasPrefix(s, "00"):
+		international, s = true, s[2:]
+	}
+
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", false, errors.Wrapf(ErrInvalidNumber, "%q contains %q", raw, r)
+		}
+	}
+	return b.String(), international, nil
+}
+
+func defaultCountryOf(raw, defaultCountry string) (Country, error) {
+	if defaultCountry == "" {
+		return Country{}, errors.Wrapf(ErrUnknownCountry, "%q is in national format but no default country is set", raw)
+	}
+	country, ok := LookupCountry(defaultCountry)
+	if !ok {
+		return Country{}, errors.Wrapf(ErrUnknownCountry, "%q", defaultCountry)
+	}
+	return country, nil
+}
+
+// validFor reports whether digits, in international format, is a number of country
+func validFor(country Country, digits string) bool {
+	return strings.HasPrefix(digits, country.DialCode) && hasLength(country, len(digits)-len(country.DialCode))
+}
+
+func hasLength(country Country, n int) bool {
+	for _, l := range country.NationalLengths {
+		if l == n {
+			return true
+		}
+	}
+	return false
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,101 @@
+package phone_test
+
+import (
+	"testing"
+
+	"github.com/Uchencho/go-termii/phone"
+	"github.com/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	table := []struct {
+		name           string
+		raw            string
+		defaultCountry string
+		expected       string
+	}{
+		{name: "National format with trunk prefix", raw: "08031234567", defaultCountry: "NG", expected: "2348031234567"},
+		{name: "National format with spacing", raw: "0803 123 4567", defaultCountry: "ng", expected: "2348031234567"},
+		{name: "International format with plus", raw: "+234 803 123 4567", expected: "2348031234567"},
+		{name: "International format with 00", raw: "00234-803-123-4567", expected: "2348031234567"},
+		{name: "International format without plus", raw: "2348031234567", defaultCountry: "NG", expected: "2348031234567"},
+		{name: "National number without trunk prefix", raw: "8031234567", defaultCountry: "234", expected: "2348031234567"},
+		{name: "Ghana national format", raw: "024 123 4567", defaultCountry: "GH", expected: "233241234567"},
+		{name: "Kenya international in another default country", raw: "+254 712 345678", defaultCountry: "NG", expected: "254712345678"},
+		{name: "Number of an unlisted country", raw: "+33 6 12 34 56 78", expected: "33612345678"},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			number, err := phone.Normalize(tt.raw, tt.defaultCountry)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, number)
+		})
+	}
+}
+
+func TestNormalizeErrors(t *testing.T) {
+	table := []struct {
+		name           string
+		raw            string
+		defaultCountry string
+		expected       error
+	}{
+		{name: "Empty number", raw: " ", defaultCountry: "NG", expected: phone.ErrInvalidNumber},
+		{name: "Letters", raw: "0803abc4567", defaultCountry: "NG", expected: phone.ErrInvalidNumber},
+		{name: "Wrong length for country", raw: "0803123456", defaultCountry: "NG", expected: phone.ErrInvalidNumber},
+		{name: "Too long", raw: "+2348031234567890", expected: phone.ErrInvalidNumber},
+		{name: "National format without default country", raw: "08031234567", expected: phone.ErrUnknownCountry},
+		{name: "Unknown default country", raw: "08031234567", defaultCountry: "XX", expected: phone.ErrUnknownCountry},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := phone.Normalize(tt.raw, tt.defaultCountry)
+			assert.True(t, errors.Is(err, tt.expected), "got %v", err)
+		})
+	}
+}
+
+func TestE164(t *testing.T) {
+	number, err := phone.E164("0803 123 4567", "NG")
+	t.Run("Number is prefixed with plus", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Equal(t, "+2348031234567", number)
+	})
+
+	t.Run("Only international numbers with plus are E.164", func(t *testing.T) {
+		assert.True(t, phone.IsE164("+2348031234567"))
+		assert.False(t, phone.IsE164("2348031234567"))
+		assert.False(t, phone.IsE164("+23480312345"))
+		assert.False(t, phone.IsE164("+0348031234567"))
+	})
+}
+
+func TestDetectCountry(t *testing.T) {
+	table := []struct {
+		number   string
+		expected string
+	}{
+		{number: "2348031234567", expected: "NG"},
+		{number: "+233241234567", expected: "GH"},
+		{number: "27821234567", expected: "ZA"},
+		{number: "256712345678", expected: "UG"},
+		{number: "447911123456", expected: "GB"},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.number, func(t *testing.T) {
+			country, ok := phone.DetectCountry(tt.number)
+			assert.True(t, ok)
+			assert.Equal(t, tt.expected, country.ISO)
+		})
+	}
+
+	t.Run("Unlisted prefix is not detected", func(t *testing.T) {
+		_, ok := phone.DetectCountry("33612345678")
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,151 @@
+package gotermii
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// PaginationLinks is a representation of the links of a paginated response
+type PaginationLinks struct {
+	First string `json:"first"`
+	Last  string `json:"last"`
+	Prev  string `json:"prev"`
+	Next  string `json:"next"`
+}
+
+// PaginationMeta is a representation of the meta object of a paginated response
+type PaginationMeta struct {
+	CurrentPage int    `json:"current_page"`
+	From        int    `json:"from"`
+	LastPage    int    `json:"last_page"`
+	Path        string `json:"path"`
+	PerPage     int    `json:"per_page"`
+	To          int    `json:"to"`
+	Total       int    `json:"total"`
+}
+
+// Phonebook is a representation of a phonebook
+type Phonebook struct {
+	ID                    string `json:"id"`
+	Name                  string `json:"name"`
+	TotalNumberOfContacts int    `json:"total_number_of_contacts"`
+	DateCreated           string `json:"date_created"`
+	LastUpdated           string `json:"last_updated"`
+}
+
+// ListPhonebooksResponse is a representation of a list phonebooks response
+type ListPhonebooksResponse struct {
+	Data  []Phonebook     `json:"data"`
+	Links PaginationLinks `json:"links"`
+	Meta  PaginationMeta  `json:"meta"`
+}
+
+// PhonebookRequest is a representation of a create or update phonebook request
+type PhonebookRequest struct {
+	APIKey        string `json:"api_key"`
+	PhonebookName string `json:"phonebook_name"`
+	Description   string `json:"description"`
+}
+
+// PhonebookResponse is a representation of a create, update or delete phonebook response
+type PhonebookResponse struct {
+	Message string `json:"message"`
+}
+
+// ListPhonebooks returns the first page of phonebooks.
+// See docs https://developers.termii.com/phonebook#fetch-phonebooks for more details
+func (c Client) ListPhonebooks() (ListPhonebooksResponse, error) {
+	return c.ListPhonebooksPageWithContext(context.Background(), 1)
+}
+
+// ListPhonebooksWithContext is like ListPhonebooks but carries ctx through to the underlying http request
+func (c Client) ListPhonebooksWithContext(ctx context.Context) (ListPhonebooksResponse, error) {
+	return c.ListPhonebooksPageWithContext(ctx, 1)
+}
+
+// ListPhonebooksPage returns a page of phonebooks, pages start at 1
+func (c Client) ListPhonebooksPage(page int) (ListPhonebooksResponse, error) {
+	return c.ListPhonebooksPageWithContext(context.Background(), page)
+}
+
+// ListPhonebooksPageWithContext is like ListPhonebooksPage but carries ctx through to the underlying http request
+func (c Client) ListPhonebooksPageWithContext(ctx context.Context, page int) (ListPhonebooksResponse, error) {
+	rURL := fmt.Sprintf("%s?api_key=%s", EndpointPhonebooks, c.config.APIKey)
+	if page > 1 {
+		rURL = fmt.Sprintf("%s&page=%d", rURL, page)
+	}
+
+	var Response ListPhonebooksResponse
+	if err := c.makeRequest(ctx, http.MethodGet, EndpointPhonebooks, rURL, nil, &Response); err != nil {
+		return ListPhonebooksResponse{}, errors.Wrap(err, "error in making request to list phonebooks")
+	}
+	return Response, nil
+}
+
+// CreatePhonebook creates a new phonebook.
+// See docs https://developers.termii.com/phonebook#create-a-phonebook for more details
+func (c Client) CreatePhonebook(req PhonebookRequest) (PhonebookResponse, error) {
+	return c.CreatePhonebookWithContext(context.Background(), req)
+}
+
+// CreatePhonebookWithContext is like CreatePhonebook but carries ctx through to the underlying http request
+func (c Client) CreatePhonebookWithContext(ctx context.Context, req PhonebookRequest) (PhonebookResponse, error) {
+	rURL := string(EndpointPhonebooks)
+	req.APIKey = c.config.APIKey
+
+	var Response PhonebookResponse
+	if err := c.makeRequest(ctx, http.MethodPost, EndpointPhonebooks, rURL, req, &Response); err != nil {
+		return PhonebookResponse{}, errors.Wrap(err, "error in making request to create phonebook")
+	}
+	return Response, nil
+}
+
+// UpdatePhonebook updates the name and description of an existing phonebook.
+// See docs https://developers.termii.com/phonebook#update-phonebook for more details
+func (c Client) UpdatePhonebook(phonebookID string, req PhonebookRequest) (PhonebookResponse, error) {
+	return c.UpdatePhonebookWithContext(context.Background(), phonebookID, req)
+}
+
+// UpdatePhonebookWithContext is like UpdatePhonebook but carries ctx through to the underlying http request
+func (c Client) UpdatePhonebookWithContext(ctx context.Context, phonebookID string, req PhonebookRequest) (PhonebookResponse, error) {
+	if phonebookID == "" {
+		return PhonebookResponse{}, errors.New("phonebook id is required")
+	}
+	rURL := phonebookURL(phonebookID)
+	req.APIKey = c.config.APIKey
+
+	var Response PhonebookResponse
+	if err := c.makeRequest(ctx, http.MethodPatch, EndpointPhonebook, rURL, req, &Response); err != nil {
+		return PhonebookResponse{}, errors.Wrap(err, "error in making request to update phonebook")
+	}
+	return Response, nil
+}
+
+// DeletePhonebook deletes a phonebook and its contacts.
+// See docs https://developers.termii.com/phonebook#delete-phonebook for more details
+func (c Client) DeletePhonebook(phonebookID string) (PhonebookResponse, error) {
+	return c.DeletePhonebookWithContext(context.Background(), phonebookID)
+}
+
+// DeletePhonebookWithContext is like DeletePhonebook but carries ctx through to the underlying http request
+func (c Client) DeletePhonebookWithContext(ctx context.Context, phonebookID string) (PhonebookResponse, error) {
+	if phonebookID == "" {
+		return PhonebookResponse{}, errors.New("phonebook id is required")
+	}
+	rURL := fmt.Sprintf("%s?api_key=%s", phonebookURL(phonebookID), c.config.APIKey)
+
+	var Response PhonebookResponse
+	if err := c.makeRequest(ctx, http.MethodDelete, EndpointPhonebook, rURL, nil, &Response); err != nil {
+		return PhonebookResponse{}, errors.Wrap(err, "error in making request to delete phonebook")
+	}
+	return Response, nil
+}
+
+func phonebookURL(phonebookID string) string {
+	return strings.Replace(string(EndpointPhonebook), "{phonebook_id}", url.PathEscape(phonebookID), 1)
+}
@@ -0,0 +1,248 @@
+package gotermii
+
+import (
+	"context"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Uchencho/go-termii/phone"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Errors returned when estimating the cost of a request
+var (
+	ErrNoRateTable         = errors.New("no rate table configured")
+	ErrNoRate              = errors.New("no rate")
+	ErrInsufficientBalance = errors.New("insufficient balance")
+)
+
+// DefaultRateCountry is the country key of the rates used for recipients whose country has no rates
+const DefaultRateCountry = "default"
+
+// RateTable is a representation of the price of a message page, per recipient country and channel.
+//
+// A rate table is written in JSON or YAML, countries are keyed by ISO code or dialing code:
+//
+//	currency: NGN
+//	rates:
+//	  NG: {generic: 4, dnd: 4.5, whatsapp: 2.5}
+//	  GH: {generic: 12}
+//	  default: {generic: 20}
+type RateTable struct {
+	Currency string `json:"currency" yaml:"currency"`
+	// Rates are keyed by ISO country code, or DefaultRateCountry, then by channel
+	Rates map[string]map[Channel]float64 `json:"rates" yaml:"rates"`
+}
+
+// LoadRateTable reads a rate table in JSON or YAML from r
+func LoadRateTable(r io.Reader) (RateTable, error) {
+	var raw struct {
+		Currency string                        `yaml:"currency"`
+		Rates    map[string]map[string]float64 `yaml:"rates"`
+	}
+	// YAML is a superset of JSON, so a single decoder reads both
+	if err := yaml.NewDecoder(r).Decode(&raw); err != nil {
+		return RateTable{}, errors.Wrap(err, "pricing - unable to decode rate table")
+	}
+
+	table := RateTable{Currency: strings.ToUpper(strings.TrimSpace(raw.Currency)), Rates: make(map[string]map[Channel]float64)}
+	for country, channels := range raw.Rates {
+		key := DefaultRateCountry
+		if !strings.EqualFold(country, DefaultRateCountry) {
+			c, ok := phone.LookupCountry(country)
+			if !ok {
+				return RateTable{}, errors.Errorf("pricing - unknown country %q", country)
+			}
+			key = c.ISO
+		}
+
+		rates := make(map[Channel]float64, len(channels))
+		for name, rate := range channels {
+			channel, err := ParseChannel(name)
+			if err != nil {
+				return RateTable{}, errors.Wrapf(err, "pricing - rates of %s", country)
+			}
+			if rate < 0 {
+				return RateTable{}, errors.Errorf("pricing - negative %s rate for %s", channel, country)
+			}
+			rates[channel] = rate
+		}
+		table.Rates[key] = rates
+	}
+	return table, nil
+}
+
+// LoadRateTableFile reads a rate table in JSON or YAML from the file at path
+func LoadRateTableFile(path string) (RateTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return RateTable{}, errors.Wrap(err, "pricing - unable to open rate table")
+	}
+	defer f.Close()
+	return LoadRateTable(f)
+}
+
+// Rate returns the price of a page sent to a recipient in country, an ISO code, over channel.
+// The default rates are used when the country has no rate for the channel.
+func (t RateTable) Rate(country string, channel Channel) (float64, error) {
+	channel = channel.canonical()
+	if rate, ok := t.Rates[strings.ToUpper(country)][channel]; ok {
+		return rate, nil
+	}
+	if rate, ok := t.Rates[DefaultRateCountry][channel]; ok {
+		return rate, nil
+	}
+	if country == "" {
+		country = "unknown country"
+	}
+	return 0, errors.Wrapf(ErrNoRate, "%s over %s", country, channel)
+}
+
+// WithRateTable sets the rate table used to estimate the cost of requests
+func WithRateTable(table RateTable) Option {
+	return func(c *Client) {
+		c.rates = &table
+	}
+}
+
+// CountryCost is a representation of the estimated spend on the recipients of a country
+type CountryCost struct {
+	Recipients int
+	Rate       float64
+	Cost       float64
+}
+
+// CostEstimate is a representation of the predicted spend of a request
+type CostEstimate struct {
+	Currency   string
+	Channel    Channel
+	Recipients int
+	// Pages is the number of pages each recipient is billed for
+	Pages   int
+	Segment SegmentInfo
+	// ByCountry is keyed by ISO country code, recipients of an undetected country are keyed by DefaultRateCountry
+	ByCountry map[string]CountryCost
+	Total     float64
+}
+
+// EstimateCost predicts the spend of sending req, a SendMessageRequest, BulkMessageRequest or SendTokenRequest,
+// from the rate table set with WithRateTable. Each recipient is billed for every page of the message at the
+// rate of their country, whatsapp and email messages are billed as a single page.
+func (c Client) EstimateCost(req interface{}) (CostEstimate, error) {
+	if c.rates == nil {
+		return CostEstimate{}, ErrNoRateTable
+	}
+
+	var (
+		recipients []string
+		channel    Channel
+		smsType    SMSType
+		text       string
+	)
+	switch r := req.(type) {
+	case SendMessageRequest:
+		recipients, channel, smsType, text = []string{r.To}, r.Channel, r.Type, r.Sms
+	case BulkMessageRequest:
+		recipients, channel, smsType, text = r.To, r.Channel, r.Type, r.Sms
+	case SendTokenRequest:
+		recipients, channel, text = []string{r.To}, r.Channel, tokenText(r)
+		if r.Channel.canonical() == ChannelEmail {
+			recipients = []string{r.EmailAddress}
+		}
+	default:
+		return CostEstimate{}, errors.Errorf("pricing - unable to estimate the cost of %T", req)
+	}
+	channel = channel.canonical()
+
+	estimate := CostEstimate{
+		Currency:   c.rates.Currency,
+		Channel:    channel,
+		Recipients: len(recipients),
+		Segment:    AnalyzeSMSWithType(text, smsType),
+		ByCountry:  make(map[string]CountryCost),
+	}
+	estimate.Pages = estimate.Segment.Pages
+	if channel == ChannelWhatsApp || channel == ChannelEmail {
+		estimate.Pages = 1
+	}
+
+	for _, to := range recipients {
+		country := DefaultRateCountry
+		if channel != ChannelEmail {
+			country = c.recipientCountry(to)
+		}
+
+		cost, ok := estimate.ByCountry[country]
+		if !ok {
+			rate, err := c.rates.Rate(country, channel)
+			if err != nil {
+				return CostEstimate{}, err
+			}
+			cost.Rate = rate
+		}
+		cost.Recipients++
+		cost.Cost += cost.Rate * float64(estimate.Pages)
+		estimate.ByCountry[country] = cost
+	}
+
+	countries := make([]string, 0, len(estimate.ByCountry))
+	for country := range estimate.ByCountry {
+		countries = append(countries, country)
+	}
+	// sum in a stable order so equal requests have equal totals
+	sort.Strings(countries)
+	for _, country := range countries {
+		estimate.Total += estimate.ByCountry[country].Cost
+	}
+	return estimate, nil
+}
+
+// PreflightCheck estimates the cost of req and compares it against the balance returned by GetBalance.
+// ErrInsufficientBalance is returned, along with the estimate, when the balance does not cover it.
+func (c Client) PreflightCheck(req interface{}) (CostEstimate, error) {
+	return c.PreflightCheckWithContext(context.Background(), req)
+}
+
+// PreflightCheckWithContext is like PreflightCheck but carries ctx through to the underlying http request
+func (c Client) PreflightCheckWithContext(ctx context.Context, req interface{}) (CostEstimate, error) {
+	estimate, err := c.EstimateCost(req)
+	if err != nil {
+		return CostEstimate{}, err
+	}
+
+	balance, err := c.GetBalanceWithContext(ctx)
+	if err != nil {
+		return estimate, err
+	}
+	if estimate.Currency != "" && !strings.EqualFold(estimate.Currency, balance.Currency) {
+		return estimate, errors.Errorf("pricing - rates are in %s but the balance is in %s", estimate.Currency, balance.Currency)
+	}
+	if estimate.Total > float64(balance.Balance) {
+		return estimate, errors.Wrapf(ErrInsufficientBalance, "estimated cost of %.2f %s exceeds balance of %d %s",
+			estimate.Total, estimate.Currency, balance.Balance, balance.Currency)
+	}
+	return estimate, nil
+}
+
+// recipientCountry returns the ISO code of the country of to, or DefaultRateCountry if it can not be detected
+func (c Client) recipientCountry(to string) string {
+	number, err := phone.Normalize(to, c.phoneCountry)
+	if err != nil {
+		number = to
+	}
+	if country, ok := phone.DetectCountry(number); ok {
+		return country.ISO
+	}
+	return DefaultRateCountry
+}
+
+// tokenText returns the message of a token request with its pin placeholder replaced by a pin of the requested length
+func tokenText(req SendTokenRequest) string {
+	if req.PinPlaceholder == "" {
+		return req.MessageText
+	}
+	return strings.Replace(req.MessageText, req.PinPlaceholder, strings.Repeat("0", req.PinLength), -1)
+}
@@ -0,0 +1,144 @@
+package gotermii_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	termii "github.com/Uchencho/go-termii"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadRateTable(t *testing.T) {
+	expected := termii.RateTable{
+		Currency: "NGN",
+		Rates: map[string]map[termii.Channel]float64{
+			"NG":      {termii.ChannelGeneric: 4, termii.ChannelDND: 4.5, termii.ChannelWhatsApp: 2.5},
+			"GH":      {termii.ChannelGeneric: 12},
+			"default": {termii.ChannelGeneric: 20, termii.ChannelEmail: 1},
+		},
+	}
+
+	for _, name := range []string{"rates.yaml", "rates.json"} {
+		t.Run(name, func(t *testing.T) {
+			table, err := termii.LoadRateTableFile(filepath.Join("testdata", name))
+			assert.NoError(t, err)
+			assert.Equal(t, expected, table)
+		})
+	}
+
+	t.Run("Unknown channel is rejected", func(t *testing.T) {
+		_, err := termii.LoadRateTable(strings.NewReader(`{"rates": {"NG": {"pigeon": 1}}}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("Unknown country is rejected", func(t *testing.T) {
+		_, err := termii.LoadRateTable(strings.NewReader(`{"rates": {"XX": {"generic": 1}}}`))
+		assert.Error(t, err)
+	})
+
+	t.Run("Malformed input is rejected", func(t *testing.T) {
+		for _, input := range []string{"0: [:!00 \xef", "rates: {NG: [", "currency: [NGN"} {
+			assert.NotPanics(t, func() {
+				_, err := termii.LoadRateTable(strings.NewReader(input))
+				assert.Error(t, err)
+			})
+		}
+	})
+}
+
+func TestEstimateCost(t *testing.T) {
+	table, _ := termii.LoadRateTableFile(filepath.Join("testdata", "rates.yaml"))
+	c, _ := termii.New(termii.WithAPIKey(termiiTestApiKey), termii.WithBaseURL("https://api.ng.termii.com"), termii.WithRateTable(table))
+
+	estimate, err := c.EstimateCost(termii.BulkMessageRequest{
+		To:      []string{"2348031234567", "2347066554433", "233241234567", "33612345678"},
+		From:    "Acme",
+		Sms:     strings.Repeat("a", 200),
+		Type:    termii.SMSTypePlain,
+		Channel: termii.ChannelGeneric,
+	})
+	t.Run("Bulk recipients are priced by country", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Equal(t, 2, estimate.Pages)
+		assert.Equal(t, 4, estimate.Recipients)
+		assert.Equal(t, map[string]termii.CountryCost{
+			"NG":      {Recipients: 2, Rate: 4, Cost: 16},
+			"GH":      {Recipients: 1, Rate: 12, Cost: 24},
+			"default": {Recipients: 1, Rate: 20, Cost: 40},
+		}, estimate.ByCountry)
+		assert.Equal(t, 80.0, estimate.Total)
+	})
+
+	estimate, err = c.EstimateCost(termii.SendMessageRequest{
+		To: "2348031234567", From: "Acme", Sms: strings.Repeat("a", 200), Type: termii.SMSTypePlain, Channel: termii.ChannelWhatsApp,
+	})
+	t.Run("Whatsapp messages are billed as a single page", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Equal(t, 2.5, estimate.Total)
+	})
+
+	estimate, err = c.EstimateCost(termii.SendMessageRequest{
+		To: "2348031234567", From: "Acme", Sms: strings.Repeat("a", 100), Type: termii.SMSTypeUnicode, Channel: termii.ChannelGeneric,
+	})
+	t.Run("Unicode messages are billed as UCS-2", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Equal(t, termii.EncodingUCS2, estimate.Segment.Encoding)
+		assert.Equal(t, 2, estimate.Pages)
+		assert.Equal(t, 8.0, estimate.Total)
+	})
+
+	estimate, err = c.EstimateCost(termii.SendTokenRequest{
+		To:             "2348031234567",
+		Channel:        termii.ChannelDND,
+		PinLength:      6,
+		PinPlaceholder: "< 1234 >",
+		MessageText:    "Your pin is < 1234 >",
+	})
+	t.Run("Token is priced with its pin", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Equal(t, 18, estimate.Segment.Characters)
+		assert.Equal(t, 4.5, estimate.Total)
+	})
+
+	_, err = c.EstimateCost(termii.SendMessageRequest{To: "233241234567", Channel: termii.ChannelDND, Sms: "Hi"})
+	t.Run("Missing rate is reported", func(t *testing.T) {
+		assert.True(t, errors.Is(err, termii.ErrNoRate))
+	})
+
+	_, err = termii.Client{}.EstimateCost(termii.SendMessageRequest{})
+	t.Run("Missing rate table is reported", func(t *testing.T) {
+		assert.True(t, errors.Is(err, termii.ErrNoRateTable))
+	})
+}
+
+func TestPreflightCheck(t *testing.T) {
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"user":"Acme","balance":10,"currency":"NGN"}`))
+	}))
+	defer termiiService.Close()
+
+	table, _ := termii.LoadRateTableFile(filepath.Join("testdata", "rates.yaml"))
+	c, _ := termii.New(termii.WithAPIKey(termiiTestApiKey), termii.WithBaseURL(termiiService.URL), termii.WithRateTable(table))
+
+	req := termii.BulkMessageRequest{
+		To: []string{"2348031234567", "2347066554433"}, From: "Acme", Sms: "Hello", Type: termii.SMSTypePlain, Channel: termii.ChannelGeneric,
+	}
+	estimate, err := c.PreflightCheck(req)
+	t.Run("Balance covers the estimate", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Equal(t, 8.0, estimate.Total)
+	})
+
+	req.To = append(req.To, "2348109077743")
+	estimate, err = c.PreflightCheck(req)
+	t.Run("Balance does not cover the estimate", func(t *testing.T) {
+		assert.True(t, termii.IsInsufficientBalance(err))
+		assert.Equal(t, 12.0, estimate.Total)
+	})
+}
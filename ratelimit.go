@@ -0,0 +1,253 @@
+package gotermii
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrRateLimited is returned by a fail fast RateLimiter when a request would exceed the configured limits
+var ErrRateLimited = errors.New("client side rate limit exceeded")
+
+// EndpointGroup is a representation of a set of endpoints sharing a rate limit
+type EndpointGroup string
+
+// Endpoint groups
+const (
+	GroupMessaging EndpointGroup = "messaging"
+	GroupToken     EndpointGroup = "token"
+	GroupInsight   EndpointGroup = "insight"
+	GroupSenderID  EndpointGroup = "sender-id"
+)
+
+// endpointGroups maps endpoints to the group they are rate limited in
+var endpointGroups = map[Endpoint]EndpointGroup{
+	EndpointSendMessage:              GroupMessaging,
+	EndpointSendAutoGeneratedMessage: GroupMessaging,
+	EndpointSendBulkMessage:          GroupMessaging,
+	EndpointSetDeviceTemplate:        GroupMessaging,
+	EndpointGetHistory:               GroupMessaging,
+	EndpointPhonebooks:               GroupMessaging,
+	EndpointPhonebook:                GroupMessaging,
+	EndpointContacts:                 GroupMessaging,
+	EndpointContact:                  GroupMessaging,
+	EndpointUploadContacts:           GroupMessaging,
+	EndpointSendCampaign:             GroupMessaging,
+	EndpointCampaigns:                GroupMessaging,
+	EndpointCampaign:                 GroupMessaging,
+	EndpointSendToken:                GroupToken,
+	EndpointVerifyToken:              GroupToken,
+	EndpointGetInAppToken:            GroupToken,
+	EndpointSendVoiceToken:           GroupToken,
+	EndpointSendVoiceCall:            GroupToken,
+	EndpointSendEmailToken:           GroupToken,
+	EndpointGetBalance:               GroupInsight,
+	EndpointVerifyNumber:             GroupInsight,
+	EndpointGetStatus:                GroupInsight,
+	EndpointFetchSenderID:            GroupSenderID,
+	EndpointRegisterSender:           GroupSenderID,
+}
+
+// GroupOf returns the rate limit group of an endpoint
+func GroupOf(ep Endpoint) EndpointGroup {
+	return endpointGroups[ep]
+}
+
+// Priority is a representation of how urgently a request should be let through a RateLimiter
+type Priority int
+
+// Priorities, higher priority requests waiting on a limit are let through before lower priority ones
+const (
+	PriorityLow Priority = iota
+	PriorityNormal
+	PriorityHigh
+
+	numPriorities = int(PriorityHigh) + 1
+)
+
+type priorityKey struct{}
+
+// ContextWithPriority overrides the priority of requests made with ctx
+func ContextWithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, p)
+}
+
+// priorityOf returns the priority of a request, token requests are high priority and messaging is low
+// priority by default so OTPs are not starved by bulk messaging sharing the same api key.
+func priorityOf(ctx context.Context, ep Endpoint) Priority {
+	if p, ok := ctx.Value(priorityKey{}).(Priority); ok && p >= PriorityLow && p <= PriorityHigh {
+		return p
+	}
+	switch GroupOf(ep) {
+	case GroupToken:
+		return PriorityHigh
+	case GroupMessaging:
+		return PriorityLow
+	}
+	return PriorityNormal
+}
+
+// Limit is a representation of a token bucket, Rate requests per second with bursts of up to Burst requests
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimitConfig is a representation of the limits enforced by a RateLimiter
+type RateLimitConfig struct {
+	// Global limits every request made through the limiter, it is usually set to the limit of the termii plan
+	Global Limit
+	// Groups limits requests per endpoint group, groups without a limit are only subject to Global
+	Groups map[EndpointGroup]Limit
+	// FailFast returns ErrRateLimited instead of waiting for the limit to allow the request
+	FailFast bool
+}
+
+// RateLimiter is a client side token bucket rate limiter. A RateLimiter may be shared by
+// clients using the same api key.
+type RateLimiter struct {
+	failFast bool
+	global   *bucket
+	groups   map[EndpointGroup]*bucket
+}
+
+// NewRateLimiter creates a rate limiter enforcing cfg. Limits with a non positive rate are ignored.
+func NewRateLimiter(cfg RateLimitConfig) *RateLimiter {
+	l := &RateLimiter{
+		failFast: cfg.FailFast,
+		global:   newBucket(cfg.Global),
+		groups:   make(map[EndpointGroup]*bucket),
+	}
+	for group, limit := range cfg.Groups {
+		if b := newBucket(limit); b != nil {
+			l.groups[group] = b
+		}
+	}
+	return l
+}
+
+// WithRateLimit rate limits requests made by the client according to cfg
+func WithRateLimit(cfg RateLimitConfig) Option {
+	return WithRateLimiter(NewRateLimiter(cfg))
+}
+
+// WithRateLimiter rate limits requests made by the client with an existing, possibly shared, limiter
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(c *Client) {
+		c.limiter = l
+	}
+}
+
+// Wait blocks until a request to ep is allowed, ctx is done, or, in fail fast mode, returns ErrRateLimited
+func (l *RateLimiter) Wait(ctx context.Context, ep Endpoint) error {
+	if l == nil {
+		return nil
+	}
+	p := priorityOf(ctx, ep)
+	group := l.groups[GroupOf(ep)]
+	if err := group.take(ctx, p, l.failFast); err != nil {
+		return err
+	}
+	if err := l.global.take(ctx, p, l.failFast); err != nil {
+		group.refund()
+		return err
+	}
+	return nil
+}
+
+type bucket struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	tokens  float64
+	last    time.Time
+	waiting [numPriorities]int
+}
+
+func newBucket(limit Limit) *bucket {
+	if limit.Rate <= 0 {
+		return nil
+	}
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &bucket{rate: limit.Rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// take removes a token from the bucket, waiting for one to be available unless failFast is set.
+// While higher priority callers are waiting, lower priority callers give way to them.
+func (b *bucket) take(ctx context.Context, p Priority, failFast bool) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	queued := false
+	for {
+		b.refill(time.Now())
+		if b.tokens >= 1 && !b.higherWaiting(p) {
+			b.tokens--
+			if queued {
+				b.waiting[p]--
+			}
+			b.mu.Unlock()
+			return nil
+		}
+		if failFast {
+			b.mu.Unlock()
+			return ErrRateLimited
+		}
+		if !queued {
+			b.waiting[p]++
+			queued = true
+		}
+
+		wait := time.Millisecond
+		if b.tokens < 1 {
+			wait = time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		}
+		b.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			b.mu.Lock()
+			b.waiting[p]--
+			b.mu.Unlock()
+			return errors.Wrap(ctx.Err(), "gave up waiting for rate limit")
+		case <-timer.C:
+		}
+		b.mu.Lock()
+	}
+}
+
+func (b *bucket) refund() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens+1 <= b.burst {
+		b.tokens++
+	}
+}
+
+func (b *bucket) refill(now time.Time) {
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+}
+
+func (b *bucket) higherWaiting(p Priority) bool {
+	for i := int(p) + 1; i < numPriorities; i++ {
+		if b.waiting[i] > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+package gotermii_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	termii "github.com/Uchencho/go-termii"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitFailFast(t *testing.T) {
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"user":"Acme","balance":100,"currency":"NGN"}`))
+	}))
+	defer termiiService.Close()
+
+	c, _ := termii.New(
+		termii.WithAPIKey(termiiTestApiKey),
+		termii.WithBaseURL(termiiService.URL),
+		termii.WithRateLimit(termii.RateLimitConfig{
+			Groups:   map[termii.EndpointGroup]termii.Limit{termii.GroupInsight: {Rate: 0.1, Burst: 2}},
+			FailFast: true,
+		}),
+	)
+
+	var errs []error
+	for i := 0; i < 3; i++ {
+		_, err := c.GetBalance()
+		errs = append(errs, err)
+	}
+
+	t.Run("Requests within the burst are allowed", func(t *testing.T) {
+		assert.NoError(t, errs[0])
+		assert.NoError(t, errs[1])
+	})
+
+	t.Run("Request exceeding the limit is refused", func(t *testing.T) {
+		assert.True(t, termii.IsRateLimited(errs[2]))
+	})
+}
+
+func TestRateLimitBlocking(t *testing.T) {
+	l := termii.NewRateLimiter(termii.RateLimitConfig{
+		Groups: map[termii.EndpointGroup]termii.Limit{termii.GroupMessaging: {Rate: 20, Burst: 1}},
+	})
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, l.Wait(context.Background(), termii.EndpointSendMessage))
+	}
+	t.Run("Requests wait for tokens", func(t *testing.T) {
+		assert.True(t, time.Since(start) >= 90*time.Millisecond)
+	})
+
+	t.Run("Other groups are not limited", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.NoError(t, l.Wait(ctx, termii.EndpointSendToken))
+	})
+
+	t.Run("Waiting stops when context is done", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.Error(t, l.Wait(ctx, termii.EndpointSendMessage))
+	})
+}
+
+func TestRateLimitPriority(t *testing.T) {
+	l := termii.NewRateLimiter(termii.RateLimitConfig{Global: termii.Limit{Rate: 10, Burst: 1}})
+	assert.NoError(t, l.Wait(context.Background(), termii.EndpointGetBalance))
+
+	var (
+		mu    sync.Mutex
+		order []termii.Endpoint
+		wg    sync.WaitGroup
+	)
+	wait := func(ep termii.Endpoint) {
+		defer wg.Done()
+		l.Wait(context.Background(), ep)
+		mu.Lock()
+		order = append(order, ep)
+		mu.Unlock()
+	}
+
+	wg.Add(2)
+	go wait(termii.EndpointSendMessage)
+	time.Sleep(20 * time.Millisecond)
+	go wait(termii.EndpointSendToken)
+	wg.Wait()
+
+	t.Run("Token request pre-empts messaging request", func(t *testing.T) {
+		assert.Equal(t, []termii.Endpoint{termii.EndpointSendToken, termii.EndpointSendMessage}, order)
+	})
+}
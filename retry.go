@@ -0,0 +1,211 @@
+package gotermii
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RetryPolicy is a representation of how failed requests are retried.
+//
+// Only network errors, 429 and 5xx responses are retried. Requests that are not idempotent
+// (e.g SendMessage, SendToken) are only retried when termii could not have received them,
+// i.e the connection could not be established or the request was rate limited, unless
+// RetryNonIdempotent is set.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts including the first one, values below 2 disable retries
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts, a Retry-After sent by termii is still honoured
+	MaxBackoff time.Duration
+	// Multiplier is the factor the delay grows by after every attempt, defaults to 2
+	Multiplier float64
+	// Jitter is the fraction, between 0 and 1, of the delay that is randomised
+	Jitter float64
+	// RetryNonIdempotent allows retrying requests that may already have been processed by termii
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a policy of 3 attempts with exponential backoff starting at 200ms
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// RetryError is returned by client methods when a retry policy is enabled, it records
+// how many attempts were made before giving up
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("request failed after %d attempt(s): %v", e.Attempts, e.Err)
+}
+
+// Unwrap returns the error of the last attempt
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// Cause returns the error of the last attempt
+func (e *RetryError) Cause() error {
+	return e.Err
+}
+
+// RetryAttempts returns the number of attempts recorded in err, 1 if err does not carry a count and 0 if err is nil
+func RetryAttempts(err error) int {
+	if err == nil {
+		return 0
+	}
+	var retryErr *RetryError
+	if errors.As(err, &retryErr) {
+		return retryErr.Attempts
+	}
+	return 1
+}
+
+// WithRetryPolicy sets the retry policy used by every endpoint without a policy of its own
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithEndpointRetryPolicy sets the retry policy of a single endpoint,
+// e.g to opt SendToken into retries with RetryNonIdempotent
+func WithEndpointRetryPolicy(ep Endpoint, policy RetryPolicy) Option {
+	return func(c *Client) {
+		if c.endpointRetryPolicies == nil {
+			c.endpointRetryPolicies = make(map[Endpoint]RetryPolicy)
+		}
+		c.endpointRetryPolicies[ep] = policy
+	}
+}
+
+func (s *Client) retryPolicyFor(ep Endpoint) RetryPolicy {
+	if policy, ok := s.endpointRetryPolicies[ep]; ok {
+		return policy
+	}
+	return s.retryPolicy
+}
+
+// withRetry calls attempt until it succeeds or the retry policy of ep gives up
+func (s *Client) withRetry(ctx context.Context, method string, ep Endpoint, attempt func() error) error {
+	policy := s.retryPolicyFor(ep)
+	if policy.MaxAttempts < 2 {
+		return attempt()
+	}
+	idempotent := isIdempotent(method)
+
+	for n := 1; ; n++ {
+		err := attempt()
+		if err == nil {
+			return nil
+		}
+		if n >= policy.MaxAttempts || ctx.Err() != nil || !policy.retryable(err, idempotent) {
+			return &RetryError{Attempts: n, Err: err}
+		}
+
+		wait := policy.backoff(n)
+		if apiErr, ok := AsAPIError(err); ok && apiErr.RetryAfter > wait {
+			wait = apiErr.RetryAfter
+		}
+		s.log().retry(ctx, method, ep, n, wait, err)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return &RetryError{Attempts: n, Err: errors.Wrapf(ctx.Err(), "gave up waiting to retry %v", err)}
+		case <-timer.C:
+		}
+	}
+}
+
+// retryable reports whether err is worth another attempt
+func (p RetryPolicy) retryable(err error, idempotent bool) bool {
+	safe := idempotent || p.RetryNonIdempotent
+	if apiErr, ok := AsAPIError(err); ok {
+		switch {
+		case apiErr.StatusCode == http.StatusTooManyRequests:
+			// a throttled request was rejected before termii processed it
+			return true
+		case apiErr.StatusCode >= http.StatusInternalServerError:
+			return safe
+		default:
+			return false
+		}
+	}
+	var netErr net.Error
+	if !errors.As(err, &netErr) {
+		return false
+	}
+	return safe || notSent(err)
+}
+
+// notSent reports whether a network error happened before the request could have reached termii
+func notSent(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr)
+}
+
+// backoff returns the delay before the attempt following attempt n
+func (p RetryPolicy) backoff(n int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+	wait := float64(p.InitialBackoff) * math.Pow(multiplier, float64(n-1))
+	if p.MaxBackoff > 0 && wait > float64(p.MaxBackoff) {
+		wait = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		wait = wait * (1 - jitter + 2*jitter*rand.Float64())
+	}
+	return time.Duration(wait)
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an http date
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
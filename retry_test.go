@@ -0,0 +1,171 @@
+package gotermii_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	termii "github.com/Uchencho/go-termii"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryPolicy() termii.RetryPolicy {
+	return termii.RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+	}
+}
+
+// flakyTermii responds with failures before responding with body
+func flakyTermii(hits *int32, failures []int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		n := atomic.AddInt32(hits, 1)
+		if int(n) <= len(failures) {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(failures[n-1])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestRetryIdempotentRequest(t *testing.T) {
+	var hits int32
+	termiiService := flakyTermii(&hits, []int{http.StatusBadGateway, http.StatusServiceUnavailable}, `{"user":"Acme","balance":100,"currency":"NGN"}`)
+	defer termiiService.Close()
+
+	c, _ := termii.New(
+		termii.WithAPIKey(termiiTestApiKey),
+		termii.WithBaseURL(termiiService.URL),
+		termii.WithRetryPolicy(testRetryPolicy()),
+	)
+
+	resp, err := c.GetBalance()
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Request is retried until it succeeds", func(t *testing.T) {
+		assert.Equal(t, int32(3), atomic.LoadInt32(&hits))
+		assert.Equal(t, 100, resp.Balance)
+	})
+}
+
+func TestRetryNonIdempotentRequest(t *testing.T) {
+	var req termii.SendMessageRequest
+	fileToStruct(filepath.Join("testdata", "send_message_request.json"), &req)
+
+	table := []struct {
+		name             string
+		opts             []termii.Option
+		failures         []int
+		expectedHits     int32
+		expectedAttempts int
+		expectError      bool
+	}{
+		{
+			name:             "Server error is not retried",
+			opts:             []termii.Option{termii.WithRetryPolicy(testRetryPolicy())},
+			failures:         []int{http.StatusBadGateway},
+			expectedHits:     1,
+			expectedAttempts: 1,
+			expectError:      true,
+		},
+		{
+			name:         "Rate limited request is retried",
+			opts:         []termii.Option{termii.WithRetryPolicy(testRetryPolicy())},
+			failures:     []int{http.StatusTooManyRequests},
+			expectedHits: 2,
+		},
+		{
+			name: "Server error is retried when endpoint opts in",
+			opts: []termii.Option{
+				termii.WithRetryPolicy(testRetryPolicy()),
+				termii.WithEndpointRetryPolicy(termii.EndpointSendMessage, termii.RetryPolicy{
+					MaxAttempts:        2,
+					InitialBackoff:     time.Millisecond,
+					RetryNonIdempotent: true,
+				}),
+			},
+			failures:     []int{http.StatusBadGateway},
+			expectedHits: 2,
+		},
+		{
+			name: "Attempts are exhausted",
+			opts: []termii.Option{termii.WithEndpointRetryPolicy(termii.EndpointSendMessage, termii.RetryPolicy{
+				MaxAttempts:        2,
+				InitialBackoff:     time.Millisecond,
+				RetryNonIdempotent: true,
+			})},
+			failures:         []int{http.StatusBadGateway, http.StatusBadGateway},
+			expectedHits:     2,
+			expectedAttempts: 2,
+			expectError:      true,
+		},
+	}
+
+	for _, entry := range table {
+		var hits int32
+		termiiService := flakyTermii(&hits, entry.failures, `{"message_id":"1","message":"Successfully Sent","balance":9,"user":"Acme"}`)
+
+		opts := append([]termii.Option{termii.WithAPIKey(termiiTestApiKey), termii.WithBaseURL(termiiService.URL)}, entry.opts...)
+		c, _ := termii.New(opts...)
+		_, err := c.SendMessage(req)
+		termiiService.Close()
+
+		t.Run(fmt.Sprintf("%s - Number of requests is as expected", entry.name), func(t *testing.T) {
+			assert.Equal(t, entry.expectedHits, atomic.LoadInt32(&hits))
+		})
+
+		if !entry.expectError {
+			t.Run(fmt.Sprintf("%s - No error is returned", entry.name), func(t *testing.T) {
+				assert.NoError(t, err)
+			})
+			continue
+		}
+
+		t.Run(fmt.Sprintf("%s - Error records attempts", entry.name), func(t *testing.T) {
+			assert.Error(t, err)
+			assert.Equal(t, entry.expectedAttempts, termii.RetryAttempts(err))
+			_, ok := termii.AsAPIError(err)
+			assert.True(t, ok)
+		})
+	}
+}
+
+func TestRetryAfterIsHonoured(t *testing.T) {
+	var hits int32
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"user":"Acme","balance":100,"currency":"NGN"}`))
+	}))
+	defer termiiService.Close()
+
+	c, _ := termii.New(
+		termii.WithAPIKey(termiiTestApiKey),
+		termii.WithBaseURL(termiiService.URL),
+		termii.WithRetryPolicy(testRetryPolicy()),
+	)
+
+	start := time.Now()
+	_, err := c.GetBalance()
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Retry waits for Retry-After", func(t *testing.T) {
+		assert.True(t, time.Since(start) >= time.Second)
+	})
+}
@@ -0,0 +1,175 @@
+package gotermii
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// Encoding is a representation of the character set an sms is sent in
+type Encoding string
+
+// Encodings an sms can be sent in
+const (
+	EncodingGSM7 Encoding = "GSM-7"
+	EncodingUCS2 Encoding = "UCS-2"
+)
+
+// Number of units that fit in a page. Pages of a concatenated sms carry a header, which leaves
+// less room for the text.
+const (
+	gsm7SinglePage = 160
+	gsm7MultiPage  = 153
+	ucs2SinglePage = 70
+	ucs2MultiPage  = 67
+)
+
+// gsm7Basic are the characters of the GSM 03.38 default alphabet, each costs one septet
+const gsm7Basic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+// gsm7Extended are the characters of the GSM 03.38 extension table, each costs two septets
+const gsm7Extended = "\f^{}\\[~]|€"
+
+// SegmentInfo is a representation of how an sms is split into billable pages
+type SegmentInfo struct {
+	Encoding Encoding
+	// Characters is the number of characters in the text
+	Characters int
+	// Units is the number of septets of a GSM-7 text or the number of UTF-16 code units of a UCS-2 text
+	Units int
+	// ExtendedCharacters is the number of characters of the GSM-7 extension table, which cost two septets each
+	ExtendedCharacters int
+	Pages              int
+	// PerPage is the number of units that fit in each page of the text
+	PerPage int
+	// Remaining is the number of units that can be added before another page is needed
+	Remaining int
+	// UnicodeCharacters are the distinct characters outside of GSM-7 that force the text into UCS-2
+	UnicodeCharacters []string
+}
+
+// AnalyzeSMS reports the encoding of text and the number of pages it is billed as
+func AnalyzeSMS(text string) SegmentInfo {
+	return analyzeSMS(text, EncodingGSM7)
+}
+
+// AnalyzeSMSWithType is like AnalyzeSMS for a text sent as smsType. Termii sends unicode messages
+// as UCS-2 whatever their characters.
+func AnalyzeSMSWithType(text string, smsType SMSType) SegmentInfo {
+	if smsType.canonical() == SMSTypeUnicode {
+		return analyzeSMS(text, EncodingUCS2)
+	}
+	return analyzeSMS(text, EncodingGSM7)
+}
+
+// analyzeSMS reports the pages of text sent in encoding, or in UCS-2 if text does not fit in GSM-7
+func analyzeSMS(text string, encoding Encoding) SegmentInfo {
+	info := SegmentInfo{Encoding: encoding}
+
+	seen := make(map[rune]bool)
+	for _, r := range text {
+		info.Characters++
+		switch {
+		case strings.ContainsRune(gsm7Basic, r):
+		case strings.ContainsRune(gsm7Extended, r):
+			info.ExtendedCharacters++
+		default:
+			info.Encoding = EncodingUCS2
+			if !seen[r] {
+				seen[r] = true
+				info.UnicodeCharacters = append(info.UnicodeCharacters, string(r))
+			}
+		}
+	}
+
+	costs := make([]int, 0, info.Characters)
+	for _, r := range text {
+		c := info.Encoding.cost(r)
+		costs = append(costs, c)
+		info.Units += c
+	}
+
+	single, multi := info.Encoding.pageSizes()
+	if info.Units <= single {
+		info.Pages, info.PerPage = 1, single
+		info.Remaining = single - info.Units
+		return info
+	}
+
+	// characters are never split across pages, so a page may be left with unused units
+	info.PerPage = multi
+	used := 0
+	info.Pages = 1
+	for _, c := range costs {
+		if used+c > multi {
+			info.Pages++
+			used = 0
+		}
+		used += c
+	}
+	info.Remaining = multi - used
+	return info
+}
+
+// cost returns the number of units r takes up in a text of encoding e
+func (e Encoding) cost(r rune) int {
+	if e == EncodingUCS2 {
+		return len(utf16.Encode([]rune{r}))
+	}
+	if strings.ContainsRune(gsm7Extended, r) {
+		return 2
+	}
+	return 1
+}
+
+func (e Encoding) pageSizes() (single, multi int) {
+	if e == EncodingUCS2 {
+		return ucs2SinglePage, ucs2MultiPage
+	}
+	return gsm7SinglePage, gsm7MultiPage
+}
+
+// WithSMSPageLimit refuses to send messages whose sms would be billed as more than maxPages pages,
+// the request is rejected with a *ValidationError before it is sent
+func WithSMSPageLimit(maxPages int) Option {
+	return func(c *Client) {
+		c.smsPageLimit = maxPages
+		c.smsPageWarning = nil
+	}
+}
+
+// WithSMSPageWarning calls warn with the request and segment info of messages whose sms would be billed
+// as more than maxPages pages. Unlike WithSMSPageLimit, the message is still sent.
+func WithSMSPageWarning(maxPages int, warn func(request string, info SegmentInfo)) Option {
+	return func(c *Client) {
+		c.smsPageLimit = maxPages
+		c.smsPageWarning = warn
+	}
+}
+
+// checkPages applies the page limit of the client to an sms of smsType sent over channel. Whatsapp
+// messages are not split into pages and are never checked.
+func (c Client) checkPages(request string, channel Channel, smsType SMSType, sms string) error {
+	if c.smsPageLimit <= 0 || channel.canonical() == ChannelWhatsApp {
+		return nil
+	}
+
+	info := AnalyzeSMSWithType(sms, smsType)
+	if info.Pages <= c.smsPageLimit {
+		return nil
+	}
+	if c.smsPageWarning != nil {
+		c.smsPageWarning(request, info)
+		return nil
+	}
+
+	msg := fmt.Sprintf("would be sent as %d %s pages, more than the limit of %d", info.Pages, info.Encoding, c.smsPageLimit)
+	switch {
+	case len(info.UnicodeCharacters) > 0:
+		msg += fmt.Sprintf(", UCS-2 is forced by %q", strings.Join(info.UnicodeCharacters, ""))
+	case info.Encoding == EncodingUCS2:
+		msg += fmt.Sprintf(", UCS-2 is forced by the %s sms type", SMSTypeUnicode)
+	}
+	return &ValidationError{Request: request, Fields: []FieldError{{Field: "sms", Message: msg}}}
+}
@@ -0,0 +1,143 @@
+package gotermii_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	termii "github.com/Uchencho/go-termii"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeSMS(t *testing.T) {
+	table := []struct {
+		name     string
+		text     string
+		expected termii.SegmentInfo
+	}{
+		{
+			name:     "Plain text fits a single page",
+			text:     "Your pin is 1234",
+			expected: termii.SegmentInfo{Encoding: termii.EncodingGSM7, Characters: 16, Units: 16, Pages: 1, PerPage: 160, Remaining: 144},
+		},
+		{
+			name:     "Full single GSM-7 page",
+			text:     strings.Repeat("a", 160),
+			expected: termii.SegmentInfo{Encoding: termii.EncodingGSM7, Characters: 160, Units: 160, Pages: 1, PerPage: 160, Remaining: 0},
+		},
+		{
+			name:     "Concatenated GSM-7 pages",
+			text:     strings.Repeat("a", 161),
+			expected: termii.SegmentInfo{Encoding: termii.EncodingGSM7, Characters: 161, Units: 161, Pages: 2, PerPage: 153, Remaining: 145},
+		},
+		{
+			name: "Extended characters cost two septets",
+			text: "Price: €5 {promo}",
+			expected: termii.SegmentInfo{
+				Encoding: termii.EncodingGSM7, Characters: 17, Units: 20, ExtendedCharacters: 3, Pages: 1, PerPage: 160, Remaining: 140,
+			},
+		},
+		{
+			name:     "Extended character is not split across pages",
+			text:     strings.Repeat("a", 152) + "€" + "a",
+			expected: termii.SegmentInfo{Encoding: termii.EncodingGSM7, Characters: 154, Units: 155, ExtendedCharacters: 1, Pages: 1, PerPage: 160, Remaining: 5},
+		},
+		{
+			name: "Curly quotes force UCS-2",
+			text: "It’s “ready”",
+			expected: termii.SegmentInfo{
+				Encoding: termii.EncodingUCS2, Characters: 12, Units: 12, Pages: 1, PerPage: 70, Remaining: 58,
+				UnicodeCharacters: []string{"’", "“", "”"},
+			},
+		},
+		{
+			name: "Emoji take two code units",
+			text: strings.Repeat("a", 66) + "🎉",
+			expected: termii.SegmentInfo{
+				Encoding: termii.EncodingUCS2, Characters: 67, Units: 68, Pages: 1, PerPage: 70, Remaining: 2,
+				UnicodeCharacters: []string{"🎉"},
+			},
+		},
+		{
+			name: "Emoji is not split across pages",
+			text: strings.Repeat("a", 66) + "🎉" + strings.Repeat("a", 3),
+			expected: termii.SegmentInfo{
+				Encoding: termii.EncodingUCS2, Characters: 70, Units: 71, Pages: 2, PerPage: 67, Remaining: 62,
+				UnicodeCharacters: []string{"🎉"},
+			},
+		},
+	}
+
+	for _, tt := range table {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, termii.AnalyzeSMS(tt.text))
+		})
+	}
+
+	t.Run("Unicode sms type forces UCS-2", func(t *testing.T) {
+		assert.Equal(t,
+			termii.SegmentInfo{Encoding: termii.EncodingUCS2, Characters: 71, Units: 71, Pages: 2, PerPage: 67, Remaining: 63},
+			termii.AnalyzeSMSWithType(strings.Repeat("a", 71), "Unicode"))
+		assert.Equal(t, termii.AnalyzeSMS("Hello"), termii.AnalyzeSMSWithType("Hello", termii.SMSTypePlain))
+	})
+}
+
+func TestSMSPageLimit(t *testing.T) {
+	var sent int
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		sent++
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"message_id":"9122821270554876574","message":"Successfully Sent","balance":9,"user":"Peter Mcleish"}`))
+	}))
+	defer termiiService.Close()
+
+	req := termii.SendMessageRequest{
+		To:      "2347880234567",
+		From:    "Acme",
+		Sms:     "Don’t miss out " + strings.Repeat("a", 70),
+		Type:    termii.SMSTypePlain,
+		Channel: termii.ChannelGeneric,
+	}
+
+	c, _ := termii.New(termii.WithAPIKey(termiiTestApiKey), termii.WithBaseURL(termiiService.URL), termii.WithSMSPageLimit(1))
+	_, err := c.SendMessage(req)
+	t.Run("Message over the limit is refused", func(t *testing.T) {
+		validationErr, ok := termii.AsValidationError(err)
+		assert.True(t, ok)
+		assert.Equal(t, "sms", validationErr.Fields[0].Field)
+		assert.Contains(t, validationErr.Fields[0].Message, "’")
+		assert.Equal(t, 0, sent)
+	})
+
+	req.Channel = termii.ChannelWhatsApp
+	_, err = c.SendMessage(req)
+	t.Run("Whatsapp messages are not limited", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Equal(t, 1, sent)
+	})
+
+	_, err = c.SendMessage(termii.SendMessageRequest{
+		To: "2347880234567", From: "Acme", Sms: strings.Repeat("a", 100), Type: termii.SMSTypeUnicode, Channel: termii.ChannelGeneric,
+	})
+	t.Run("Unicode message over the limit is refused", func(t *testing.T) {
+		validationErr, ok := termii.AsValidationError(err)
+		assert.True(t, ok)
+		assert.Contains(t, validationErr.Fields[0].Message, "2 UCS-2 pages")
+		assert.Contains(t, validationErr.Fields[0].Message, "unicode sms type")
+		assert.Equal(t, 1, sent)
+	})
+
+	var warned termii.SegmentInfo
+	c, _ = termii.New(termii.WithAPIKey(termiiTestApiKey), termii.WithBaseURL(termiiService.URL),
+		termii.WithSMSPageWarning(1, func(request string, info termii.SegmentInfo) { warned = info }))
+	req.Channel = termii.ChannelGeneric
+	_, err = c.SendMessage(req)
+	t.Run("Message over the warning limit is sent", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Equal(t, 2, sent)
+		assert.Equal(t, 2, warned.Pages)
+		assert.Equal(t, termii.EncodingUCS2, warned.Encoding)
+	})
+}
@@ -1,6 +1,7 @@
 package gotermii
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -71,13 +72,13 @@ type AutoGeneratedMessageResponse struct {
 
 // SendMesageRequest is a representation of a send message request
 type SendMessageRequest struct {
-	To      string `json:"to"`
-	From    string `json:"from"`
-	Sms     string `json:"sms"`
-	Type    string `json:"type"`
-	Channel string `json:"channel"`
-	APIKey  string `json:"api_key"`
-	Media   Media  `json:"media,omitempty"`
+	To      string  `json:"to"`
+	From    string  `json:"from"`
+	Sms     string  `json:"sms"`
+	Type    SMSType `json:"type"`
+	Channel Channel `json:"channel"`
+	APIKey  string  `json:"api_key"`
+	Media   Media   `json:"media,omitempty"`
 }
 
 // SendMessageResponse is a representation of a send message response
@@ -116,10 +117,29 @@ type TemplateResponse struct {
 // FetchSenderID allows businesses retrieve the status of all registered sender ID
 // See docs https://developers.termii.com/sender-id#fetch-sender-id for more details
 func (c Client) FetchSenderID() (FetchSenderIdResponse, error) {
-	rURL := fmt.Sprintf("api/sender-id?api_key=%s", c.config.APIKey)
+	return c.FetchSenderIDWithContext(context.Background())
+}
+
+// FetchSenderIDWithContext is like FetchSenderID but carries ctx through to the underlying http request
+func (c Client) FetchSenderIDWithContext(ctx context.Context) (FetchSenderIdResponse, error) {
+	return c.FetchSenderIDPageWithContext(ctx, 1)
+}
+
+// FetchSenderIDPage retrieves a page of registered sender IDs, pages start at 1.
+// Use IterateSenderIDs to retrieve every page.
+func (c Client) FetchSenderIDPage(page int) (FetchSenderIdResponse, error) {
+	return c.FetchSenderIDPageWithContext(context.Background(), page)
+}
+
+// FetchSenderIDPageWithContext is like FetchSenderIDPage but carries ctx through to the underlying http request
+func (c Client) FetchSenderIDPageWithContext(ctx context.Context, page int) (FetchSenderIdResponse, error) {
+	rURL := fmt.Sprintf("%s?api_key=%s", EndpointFetchSenderID, c.config.APIKey)
+	if page > 1 {
+		rURL = fmt.Sprintf("%s&page=%d", rURL, page)
+	}
 
 	var Response FetchSenderIdResponse
-	if err := c.makeRequest(http.MethodGet, rURL, nil, &Response); err != nil {
+	if err := c.makeRequest(ctx, http.MethodGet, EndpointFetchSenderID, rURL, nil, &Response); err != nil {
 		return FetchSenderIdResponse{}, errors.Wrap(err, "error in making request to fetch sender id")
 	}
 	return Response, nil
@@ -128,12 +148,17 @@ func (c Client) FetchSenderID() (FetchSenderIdResponse, error) {
 // RegisterSender allows businesses register a sender.
 // See docs https://developers.termii.com/sender-id#request-sender-id for more details
 func (c Client) RegisterSender(req RegisterSenderIdRequest) (RegisterSenderResponse, error) {
-	rURL := "api/sender-id/request"
+	return c.RegisterSenderWithContext(context.Background(), req)
+}
+
+// RegisterSenderWithContext is like RegisterSender but carries ctx through to the underlying http request
+func (c Client) RegisterSenderWithContext(ctx context.Context, req RegisterSenderIdRequest) (RegisterSenderResponse, error) {
+	rURL := string(EndpointRegisterSender)
 	req.APIKey = c.config.APIKey
 	req.SenderID = c.config.SenderID
 
 	var Response RegisterSenderResponse
-	if err := c.makeRequest(http.MethodPost, rURL, req, &Response); err != nil {
+	if err := c.makeRequest(ctx, http.MethodPost, EndpointRegisterSender, rURL, req, &Response); err != nil {
 		return RegisterSenderResponse{}, errors.Wrap(err, "error in making request to register sender")
 	}
 	return Response, nil
@@ -141,37 +166,61 @@ func (c Client) RegisterSender(req RegisterSenderIdRequest) (RegisterSenderRespo
 
 // SendSMS allows a business to send sms. See docs https://developers.termii.com/messaging for more details
 func (c Client) SendMessage(req SendMessageRequest) (SendMessageResponse, error) {
-	rURL := "api/sms/send"
+	return c.SendMessageWithContext(context.Background(), req)
+}
+
+// SendMessageWithContext is like SendMessage but carries ctx through to the underlying http request
+func (c Client) SendMessageWithContext(ctx context.Context, req SendMessageRequest) (SendMessageResponse, error) {
+	rURL := string(EndpointSendMessage)
 	req.APIKey = c.config.APIKey
+	req.Channel = req.Channel.canonical()
+	if err := c.normalizePhone("SendMessageRequest", "to", &req.To, ""); err != nil {
+		return SendMessageResponse{}, err
+	}
+	if err := c.checkPages("SendMessageRequest", req.Channel, req.Type, req.Sms); err != nil {
+		return SendMessageResponse{}, err
+	}
 
 	var Response SendMessageResponse
-	if err := c.makeRequest(http.MethodPost, rURL, req, &Response); err != nil {
+	if err := c.makeRequest(ctx, http.MethodPost, EndpointSendMessage, rURL, req, &Response); err != nil {
 		return SendMessageResponse{}, errors.Wrap(err, "error in making request to send message")
 	}
+	c.messagesSent(ctx, string(req.Channel), 1)
 	return Response, nil
 }
 
 // SendAutoGeneratedMessage allows businesses send messages to customers using auto-generated messaging numbers.
 // See docs https://developers.termii.com/number for more details
 func (c Client) SendAutoGeneratedMessage(req AutoGeneratedMessageRequest) (AutoGeneratedMessageResponse, error) {
-	rURL := "api/sms/number/send"
+	return c.SendAutoGeneratedMessageWithContext(context.Background(), req)
+}
+
+// SendAutoGeneratedMessageWithContext is like SendAutoGeneratedMessage but carries ctx through to the underlying http request
+func (c Client) SendAutoGeneratedMessageWithContext(ctx context.Context, req AutoGeneratedMessageRequest) (AutoGeneratedMessageResponse, error) {
+	rURL := string(EndpointSendAutoGeneratedMessage)
 	req.APIKey = c.config.APIKey
 
 	var Response AutoGeneratedMessageResponse
-	if err := c.makeRequest(http.MethodPost, rURL, req, &Response); err != nil {
+	if err := c.makeRequest(ctx, http.MethodPost, EndpointSendAutoGeneratedMessage, rURL, req, &Response); err != nil {
 		return AutoGeneratedMessageResponse{}, errors.Wrap(err, "error in making request to send message from an auto generated number")
 	}
+	c.messagesSent(ctx, InstrumentChannelNumber, 1)
 	return Response, nil
 }
 
 // Templates is a feature used to set a template for the one-time-passwords (pins) sent to their customers via whatsapp or sms.
 // See docs https://developers.termii.com/templates for more details
 func (c Client) SetDeviceTemplate(req TemplateRequest) ([]TemplateResponse, error) {
-	rURL := "api/send/template"
+	return c.SetDeviceTemplateWithContext(context.Background(), req)
+}
+
+// SetDeviceTemplateWithContext is like SetDeviceTemplate but carries ctx through to the underlying http request
+func (c Client) SetDeviceTemplateWithContext(ctx context.Context, req TemplateRequest) ([]TemplateResponse, error) {
+	rURL := string(EndpointSetDeviceTemplate)
 	req.APIKey = c.config.APIKey
 
 	var Response []TemplateResponse
-	if err := c.makeRequest(http.MethodPost, rURL, req, &Response); err != nil {
+	if err := c.makeRequest(ctx, http.MethodPost, EndpointSetDeviceTemplate, rURL, req, &Response); err != nil {
 		return []TemplateResponse{}, errors.Wrap(err, "error in making request to set device template")
 	}
 	return Response, nil
@@ -2,11 +2,12 @@ package gotermii
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
-	"log"
+	"mime/multipart"
 	"net/http"
 	"os"
 	"time"
@@ -23,8 +24,33 @@ type Config struct {
 
 // Client is a representation of a termii client
 type Client struct {
-	config Config
-	client *http.Client
+	config    Config
+	client    *http.Client
+	timeout   time.Duration
+	userAgent string
+
+	retryPolicy           RetryPolicy
+	endpointRetryPolicies map[Endpoint]RetryPolicy
+	limiter               *RateLimiter
+
+	bulkBatchSize   int
+	bulkConcurrency int
+
+	normalizePhones bool
+	phoneCountry    string
+
+	smsPageLimit   int
+	smsPageWarning func(request string, info SegmentInfo)
+
+	rates *RateTable
+
+	middleware []Middleware
+
+	logger     Logger
+	logLevel   LogLevel
+	logKeepPII bool
+
+	instrumentation Instrumentation
 }
 
 // ConfigFromEnvVars provides the default config from env vars for termii
@@ -36,47 +62,175 @@ func ConfigFromEnvVars() Config {
 	}
 }
 
-// NewClient creates a termii client using configuration variables
+// NewClient creates a termii client using configuration variables.
+// Use New or NewClientFromConfig when the configuration does not live in env vars.
 func NewClient() Client {
-	return Client{config: ConfigFromEnvVars(), client: &http.Client{Timeout: 30 * time.Second}}
+	return Client{config: ConfigFromEnvVars(), client: &http.Client{Timeout: defaultTimeout}}
 }
 
-func (s *Client) makeRequest(method, rURL string, reqBody interface{}, resp interface{}) error {
-	URL := fmt.Sprintf("%s/%s", s.config.BaseURL, rURL)
-	var body io.Reader
+// requestBody produces the body of every attempt of a request
+type requestBody struct {
+	contentType string
+	open        func() io.Reader
+	// payload is the body of a json request, it is logged at debug level
+	payload []byte
+	// streamed bodies can only be read once, requests carrying them are never retried
+	streamed bool
+}
+
+func (s *Client) makeRequest(ctx context.Context, method string, ep Endpoint, rURL string, reqBody interface{}, resp interface{}) error {
+	if v, ok := reqBody.(validatable); ok {
+		if err := v.Validate(); err != nil {
+			return err
+		}
+	}
+
+	var payload []byte
 	if reqBody != nil {
 		bb, err := json.Marshal(reqBody)
 		if err != nil {
 			return errors.Wrap(err, "client - unable to marshal request struct")
 		}
-		body = bytes.NewReader(bb)
+		payload = bb
 	}
-	req, err := http.NewRequest(method, URL, body)
-	req.Header.Set("Content-Type", "application/json")
-	if err != nil {
-		return errors.Wrap(err, "client - unable to create request body")
+
+	body := requestBody{
+		contentType: "application/json",
+		payload:     payload,
+		open: func() io.Reader {
+			if payload == nil {
+				return nil
+			}
+			return bytes.NewReader(payload)
+		},
+	}
+	return s.send(ctx, method, ep, rURL, body, resp)
+}
+
+// multipartFile is a representation of a file streamed as part of a multipart form
+type multipartFile struct {
+	field    string
+	filename string
+	content  io.Reader
+}
+
+// makeMultipartRequest streams fields and file as a multipart form. The file is read as the
+// request is sent rather than buffered, so the request is never retried.
+func (s *Client) makeMultipartRequest(ctx context.Context, ep Endpoint, rURL string, fields map[string]string, file multipartFile, resp interface{}) error {
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+
+	go func() {
+		pw.CloseWithError(writeMultipart(mw, fields, file))
+	}()
+	// unblock the writer if the request fails before the body is fully read
+	defer pr.Close()
+
+	body := requestBody{
+		contentType: mw.FormDataContentType(),
+		open:        func() io.Reader { return pr },
+		streamed:    true,
 	}
+	return s.send(ctx, http.MethodPost, ep, rURL, body, resp)
+}
 
-	res, err := s.client.Do(req)
+func writeMultipart(mw *multipart.Writer, fields map[string]string, file multipartFile) error {
+	for k, v := range fields {
+		if err := mw.WriteField(k, v); err != nil {
+			return errors.Wrap(err, "client - unable to write multipart field")
+		}
+	}
+	part, err := mw.CreateFormFile(file.field, file.filename)
 	if err != nil {
-		return errors.Wrap(err, "client - failed to execute request")
+		return errors.Wrap(err, "client - unable to create multipart file")
+	}
+	if _, err := io.Copy(part, file.content); err != nil {
+		return errors.Wrap(err, "client - unable to stream multipart file")
 	}
+	return mw.Close()
+}
 
-	var bb []byte
-	if res != nil {
-		bb, _ = ioutil.ReadAll(res.Body)
+// send executes a request, retrying it according to the retry policy of ep, and unmarshals the response into resp.
+// A request id is generated when ctx carries none, so that every attempt and log of the request shares it.
+func (s *Client) send(ctx context.Context, method string, ep Endpoint, rURL string, body requestBody, resp interface{}) error {
+	if _, ok := RequestIDFromContext(ctx); !ok {
+		ctx = ContextWithRequestID(ctx, newRequestID())
 	}
-	if os.Getenv("DEBUG_LOGS") == "true" {
-		log.Printf("got response %s", string(bb))
+	ctx, end := s.startCall(ctx, method, ep)
+	start := time.Now()
+	status, attempts, err := s.sendAttempts(ctx, method, ep, rURL, body, resp)
+	end(newCallResult(status, attempts, time.Since(start), err))
+	return err
+}
+
+// sendAttempts is send without instrumentation, it returns the status of the last attempt and the number of attempts made
+func (s *Client) sendAttempts(ctx context.Context, method string, ep Endpoint, rURL string, body requestBody, resp interface{}) (int, int, error) {
+	URL := fmt.Sprintf("%s/%s", s.config.BaseURL, rURL)
+
+	var (
+		bb       []byte
+		status   int
+		attempts int
+	)
+	attempt := func() error {
+		attempts++
+		status = 0
+		if err := s.limiter.Wait(ctx, ep); err != nil {
+			s.log().throttled(ctx, method, ep, err)
+			return err
+		}
+		var err error
+		bb, status, err = s.do(ctx, method, ep, URL, body)
+		return err
 	}
 
-	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusCreated {
-		return errors.Errorf("invalid status code received, expected 200/201/204, got %v, url=%s, with response body=%s",
-			res.StatusCode, URL, bb)
+	var err error
+	if body.streamed {
+		err = attempt()
+	} else {
+		err = s.withRetry(ctx, method, ep, attempt)
+	}
+	if err != nil {
+		return status, attempts, err
 	}
 
 	if err := json.Unmarshal(bb, &resp); err != nil {
-		return errors.Wrap(err, "unable to unmarshal response body")
+		return status, attempts, errors.Wrap(err, "unable to unmarshal response body")
+	}
+	return status, attempts, nil
+}
+
+// do executes a single attempt of a request and returns the status and the response body of a successful response
+func (s *Client) do(ctx context.Context, method string, ep Endpoint, URL string, body requestBody) ([]byte, int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, URL, body.open())
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "client - unable to create request body")
+	}
+	req.Header.Set("Content-Type", body.contentType)
+	if s.userAgent != "" {
+		req.Header.Set("User-Agent", s.userAgent)
+	}
+
+	logger := s.log()
+	logger.body(ctx, "termii request body", method, ep, body.payload)
+
+	start := time.Now()
+	res, err := s.doer().Do(req)
+	if err != nil {
+		logger.attempt(ctx, method, ep, URL, 0, time.Since(start), err)
+		return nil, 0, errors.Wrap(err, "client - failed to execute request")
+	}
+	defer res.Body.Close()
+
+	bb, _ := ioutil.ReadAll(res.Body)
+	latency := time.Since(start)
+	logger.body(ctx, "termii response body", method, ep, bb)
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusCreated {
+		apiErr := newAPIError(res, ep, bb)
+		logger.attempt(ctx, method, ep, URL, res.StatusCode, latency, apiErr)
+		return nil, res.StatusCode, apiErr
 	}
-	return nil
+	logger.attempt(ctx, method, ep, URL, res.StatusCode, latency, nil)
+	return bb, res.StatusCode, nil
 }
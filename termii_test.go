@@ -2,7 +2,9 @@ package gotermii_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -11,7 +13,9 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	termii "github.com/Uchencho/go-termii"
 
@@ -618,3 +622,917 @@ func TestGetHistorySuccess(t *testing.T) {
 		assert.Equal(t, expectedResponse, resp)
 	})
 }
+
+func TestSendMessageWithContextCancelled(t *testing.T) {
+	os.Setenv("TERMII_API_KEY", termiiTestApiKey)
+
+	received := make(chan struct{}, 1)
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ioutil.ReadAll(req.Body)
+		received <- struct{}{}
+		<-req.Context().Done()
+	}))
+	defer termiiService.Close()
+	os.Setenv("TERMII_URL", termiiService.URL)
+
+	var req termii.SendMessageRequest
+	fileToStruct(filepath.Join("testdata", "send_message_request.json"), &req)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-received
+		cancel()
+	}()
+
+	c := termii.NewClient()
+	_, err := c.SendMessageWithContext(ctx, req)
+	t.Run("Context error is returned", func(t *testing.T) {
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, context.Canceled))
+	})
+}
+
+func TestListPhonebooksSuccess(t *testing.T) {
+	os.Setenv("TERMII_API_KEY", termiiTestApiKey)
+	var (
+		expectedResponse termii.ListPhonebooksResponse
+	)
+
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		t.Run("URL and request method is as expected", func(t *testing.T) {
+			expectedURL := fmt.Sprintf("/api/phonebooks?api_key=%s&page=2", termiiTestApiKey)
+			assert.Equal(t, http.MethodGet, req.Method)
+			assert.Equal(t, expectedURL, req.RequestURI)
+		})
+
+		var resp termii.ListPhonebooksResponse
+		fileToStruct(filepath.Join("testdata", "list_phonebooks_response.json"), &resp)
+
+		w.WriteHeader(http.StatusOK)
+		bb, _ := json.Marshal(resp)
+		w.Write(bb)
+	}))
+	os.Setenv("TERMII_URL", termiiService.URL)
+
+	c := termii.NewClient()
+
+	resp, err := c.ListPhonebooksPage(2)
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Response is as expected", func(t *testing.T) {
+		fileToStruct(filepath.Join("testdata", "list_phonebooks_response.json"), &expectedResponse)
+		assert.Equal(t, expectedResponse, resp)
+	})
+}
+
+func TestCreatePhonebookSuccess(t *testing.T) {
+	os.Setenv("TERMII_API_KEY", termiiTestApiKey)
+	var (
+		expectedRequest  termii.PhonebookRequest
+		receivedBody     termii.PhonebookRequest
+		req              termii.PhonebookRequest
+		expectedResponse termii.PhonebookResponse
+	)
+
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := json.NewDecoder(req.Body).Decode(&receivedBody); err != nil {
+			log.Printf("error in unmarshalling %+v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		t.Run("URL and request method is as expected", func(t *testing.T) {
+			expectedURL := "/api/phonebooks"
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, expectedURL, req.RequestURI)
+		})
+
+		t.Run("Request is as expected", func(t *testing.T) {
+			fileToStruct(filepath.Join("testdata", "phonebook_request.json"), &expectedRequest)
+			assert.Equal(t, expectedRequest, receivedBody)
+		})
+
+		var resp termii.PhonebookResponse
+		fileToStruct(filepath.Join("testdata", "phonebook_response.json"), &resp)
+
+		w.WriteHeader(http.StatusOK)
+		bb, _ := json.Marshal(resp)
+		w.Write(bb)
+	}))
+	os.Setenv("TERMII_URL", termiiService.URL)
+	fileToStruct(filepath.Join("testdata", "phonebook_request.json"), &req)
+
+	c := termii.NewClient()
+
+	resp, err := c.CreatePhonebook(req)
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Response is as expected", func(t *testing.T) {
+		fileToStruct(filepath.Join("testdata", "phonebook_response.json"), &expectedResponse)
+		assert.Equal(t, expectedResponse, resp)
+	})
+}
+
+func TestUpdatePhonebookSuccess(t *testing.T) {
+	os.Setenv("TERMII_API_KEY", termiiTestApiKey)
+	var (
+		expectedRequest  termii.PhonebookRequest
+		receivedBody     termii.PhonebookRequest
+		req              termii.PhonebookRequest
+		expectedResponse termii.PhonebookResponse
+	)
+
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := json.NewDecoder(req.Body).Decode(&receivedBody); err != nil {
+			log.Printf("error in unmarshalling %+v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		t.Run("URL and request method is as expected", func(t *testing.T) {
+			expectedURL := "/api/phonebooks/f9c28de9-ab5a-4513-9c9f-338be8e1c390"
+			assert.Equal(t, http.MethodPatch, req.Method)
+			assert.Equal(t, expectedURL, req.RequestURI)
+		})
+
+		t.Run("Request is as expected", func(t *testing.T) {
+			fileToStruct(filepath.Join("testdata", "phonebook_request.json"), &expectedRequest)
+			assert.Equal(t, expectedRequest, receivedBody)
+		})
+
+		var resp termii.PhonebookResponse
+		fileToStruct(filepath.Join("testdata", "phonebook_response.json"), &resp)
+
+		w.WriteHeader(http.StatusOK)
+		bb, _ := json.Marshal(resp)
+		w.Write(bb)
+	}))
+	os.Setenv("TERMII_URL", termiiService.URL)
+	fileToStruct(filepath.Join("testdata", "phonebook_request.json"), &req)
+
+	c := termii.NewClient()
+
+	resp, err := c.UpdatePhonebook("f9c28de9-ab5a-4513-9c9f-338be8e1c390", req)
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Response is as expected", func(t *testing.T) {
+		fileToStruct(filepath.Join("testdata", "phonebook_response.json"), &expectedResponse)
+		assert.Equal(t, expectedResponse, resp)
+	})
+}
+
+func TestDeletePhonebookSuccess(t *testing.T) {
+	os.Setenv("TERMII_API_KEY", termiiTestApiKey)
+	var (
+		expectedResponse termii.PhonebookResponse
+	)
+
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		t.Run("URL and request method is as expected", func(t *testing.T) {
+			expectedURL := fmt.Sprintf("/api/phonebooks/f9c28de9-ab5a-4513-9c9f-338be8e1c390?api_key=%s", termiiTestApiKey)
+			assert.Equal(t, http.MethodDelete, req.Method)
+			assert.Equal(t, expectedURL, req.RequestURI)
+		})
+
+		var resp termii.PhonebookResponse
+		fileToStruct(filepath.Join("testdata", "phonebook_response.json"), &resp)
+
+		w.WriteHeader(http.StatusOK)
+		bb, _ := json.Marshal(resp)
+		w.Write(bb)
+	}))
+	os.Setenv("TERMII_URL", termiiService.URL)
+
+	c := termii.NewClient()
+
+	resp, err := c.DeletePhonebook("f9c28de9-ab5a-4513-9c9f-338be8e1c390")
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Response is as expected", func(t *testing.T) {
+		fileToStruct(filepath.Join("testdata", "phonebook_response.json"), &expectedResponse)
+		assert.Equal(t, expectedResponse, resp)
+	})
+}
+
+func TestListContactsSuccess(t *testing.T) {
+	os.Setenv("TERMII_API_KEY", termiiTestApiKey)
+	var (
+		expectedResponse termii.ListContactsResponse
+	)
+
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		t.Run("URL and request method is as expected", func(t *testing.T) {
+			expectedURL := fmt.Sprintf("/api/phonebooks/f9c28de9-ab5a-4513-9c9f-338be8e1c390/contacts?api_key=%s", termiiTestApiKey)
+			assert.Equal(t, http.MethodGet, req.Method)
+			assert.Equal(t, expectedURL, req.RequestURI)
+		})
+
+		var resp termii.ListContactsResponse
+		fileToStruct(filepath.Join("testdata", "list_contacts_response.json"), &resp)
+
+		w.WriteHeader(http.StatusOK)
+		bb, _ := json.Marshal(resp)
+		w.Write(bb)
+	}))
+	os.Setenv("TERMII_URL", termiiService.URL)
+
+	c := termii.NewClient()
+
+	resp, err := c.ListContacts("f9c28de9-ab5a-4513-9c9f-338be8e1c390")
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Response is as expected", func(t *testing.T) {
+		fileToStruct(filepath.Join("testdata", "list_contacts_response.json"), &expectedResponse)
+		assert.Equal(t, expectedResponse, resp)
+	})
+}
+
+func TestAddContactSuccess(t *testing.T) {
+	os.Setenv("TERMII_API_KEY", termiiTestApiKey)
+	var (
+		expectedRequest  termii.AddContactRequest
+		receivedBody     termii.AddContactRequest
+		req              termii.AddContactRequest
+		expectedResponse termii.AddContactResponse
+	)
+
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := json.NewDecoder(req.Body).Decode(&receivedBody); err != nil {
+			log.Printf("error in unmarshalling %+v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		t.Run("URL and request method is as expected", func(t *testing.T) {
+			expectedURL := "/api/phonebooks/f9c28de9-ab5a-4513-9c9f-338be8e1c390/contacts"
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, expectedURL, req.RequestURI)
+		})
+
+		t.Run("Request is as expected", func(t *testing.T) {
+			fileToStruct(filepath.Join("testdata", "add_contact_request.json"), &expectedRequest)
+			assert.Equal(t, expectedRequest, receivedBody)
+		})
+
+		var resp termii.AddContactResponse
+		fileToStruct(filepath.Join("testdata", "add_contact_response.json"), &resp)
+
+		w.WriteHeader(http.StatusOK)
+		bb, _ := json.Marshal(resp)
+		w.Write(bb)
+	}))
+	os.Setenv("TERMII_URL", termiiService.URL)
+	fileToStruct(filepath.Join("testdata", "add_contact_request.json"), &req)
+
+	c := termii.NewClient()
+
+	resp, err := c.AddContact("f9c28de9-ab5a-4513-9c9f-338be8e1c390", req)
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Response is as expected", func(t *testing.T) {
+		fileToStruct(filepath.Join("testdata", "add_contact_response.json"), &expectedResponse)
+		assert.Equal(t, expectedResponse, resp)
+	})
+}
+
+func TestDeleteContactSuccess(t *testing.T) {
+	os.Setenv("TERMII_API_KEY", termiiTestApiKey)
+	var (
+		expectedResponse termii.DeleteContactResponse
+	)
+
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		t.Run("URL and request method is as expected", func(t *testing.T) {
+			expectedURL := fmt.Sprintf("/api/phonebooks/f9c28de9-ab5a-4513-9c9f-338be8e1c390/contacts/3647982?api_key=%s", termiiTestApiKey)
+			assert.Equal(t, http.MethodDelete, req.Method)
+			assert.Equal(t, expectedURL, req.RequestURI)
+		})
+
+		var resp termii.DeleteContactResponse
+		fileToStruct(filepath.Join("testdata", "delete_contact_response.json"), &resp)
+
+		w.WriteHeader(http.StatusOK)
+		bb, _ := json.Marshal(resp)
+		w.Write(bb)
+	}))
+	os.Setenv("TERMII_URL", termiiService.URL)
+
+	c := termii.NewClient()
+
+	resp, err := c.DeleteContact("f9c28de9-ab5a-4513-9c9f-338be8e1c390", "3647982")
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Response is as expected", func(t *testing.T) {
+		fileToStruct(filepath.Join("testdata", "delete_contact_response.json"), &expectedResponse)
+		assert.Equal(t, expectedResponse, resp)
+	})
+}
+
+func TestUploadContactsSuccess(t *testing.T) {
+	os.Setenv("TERMII_API_KEY", termiiTestApiKey)
+	var (
+		expectedResponse termii.UploadContactsResponse
+	)
+	csv := "phone_number,first_name,last_name\n7062387894,Jane,Doe\n8031234567,John,Doe\n"
+
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := req.ParseMultipartForm(1 << 20); err != nil {
+			log.Printf("error in parsing multipart form %+v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		t.Run("URL and request method is as expected", func(t *testing.T) {
+			expectedURL := "/api/phonebooks/contacts/upload"
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, expectedURL, req.RequestURI)
+		})
+
+		t.Run("Request is as expected", func(t *testing.T) {
+			assert.Equal(t, termiiTestApiKey, req.FormValue("api_key"))
+			assert.Equal(t, "f9c28de9-ab5a-4513-9c9f-338be8e1c390", req.FormValue("pid"))
+			assert.Equal(t, "234", req.FormValue("country_code"))
+
+			file, header, err := req.FormFile("file")
+			assert.NoError(t, err)
+			assert.Equal(t, "contacts.csv", header.Filename)
+			bb, _ := ioutil.ReadAll(file)
+			assert.Equal(t, csv, string(bb))
+		})
+
+		var resp termii.UploadContactsResponse
+		fileToStruct(filepath.Join("testdata", "upload_contacts_response.json"), &resp)
+
+		w.WriteHeader(http.StatusOK)
+		bb, _ := json.Marshal(resp)
+		w.Write(bb)
+	}))
+	os.Setenv("TERMII_URL", termiiService.URL)
+
+	c := termii.NewClient()
+
+	resp, err := c.UploadContactsCSV("f9c28de9-ab5a-4513-9c9f-338be8e1c390", "234", strings.NewReader(csv))
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Response is as expected", func(t *testing.T) {
+		fileToStruct(filepath.Join("testdata", "upload_contacts_response.json"), &expectedResponse)
+		assert.Equal(t, expectedResponse, resp)
+	})
+}
+
+func TestSendCampaignSuccess(t *testing.T) {
+	os.Setenv("TERMII_API_KEY", termiiTestApiKey)
+	var (
+		expectedRequest  termii.SendCampaignRequest
+		receivedBody     termii.SendCampaignRequest
+		expectedResponse termii.SendCampaignResponse
+	)
+
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := json.NewDecoder(req.Body).Decode(&receivedBody); err != nil {
+			log.Printf("error in unmarshalling %+v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		t.Run("URL and request method is as expected", func(t *testing.T) {
+			expectedURL := "/api/sms/campaigns/send"
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, expectedURL, req.RequestURI)
+		})
+
+		t.Run("Request is as expected", func(t *testing.T) {
+			fileToStruct(filepath.Join("testdata", "send_campaign_request.json"), &expectedRequest)
+			assert.Equal(t, expectedRequest, receivedBody)
+		})
+
+		var resp termii.SendCampaignResponse
+		fileToStruct(filepath.Join("testdata", "send_campaign_response.json"), &resp)
+
+		w.WriteHeader(http.StatusOK)
+		bb, _ := json.Marshal(resp)
+		w.Write(bb)
+	}))
+	os.Setenv("TERMII_URL", termiiService.URL)
+
+	req := termii.SendCampaignRequest{
+		CountryCode:        "234",
+		SenderID:           "Acme",
+		Message:            "Hi {first_name}, our weekend sale starts now",
+		Channel:            "generic",
+		MessageType:        "Plain",
+		PhonebookID:        "f9c28de9-ab5a-4513-9c9f-338be8e1c390",
+		Delimiter:          ",",
+		RemoveDuplicate:    "yes",
+		CampaignType:       termii.CampaignTypePersonalized,
+		EnableLinkTracking: true,
+		ScheduleAt:         time.Date(2021, time.June, 30, 5, 0, 0, 0, time.UTC),
+		Timezone:           "Africa/Lagos",
+	}
+
+	c := termii.NewClient()
+
+	resp, err := c.SendCampaign(req)
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Response is as expected", func(t *testing.T) {
+		fileToStruct(filepath.Join("testdata", "send_campaign_response.json"), &expectedResponse)
+		assert.Equal(t, expectedResponse, resp)
+	})
+}
+
+func TestSendCampaignSchedule(t *testing.T) {
+	var received termii.SendCampaignRequest
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		json.NewDecoder(req.Body).Decode(&received)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"message":"Campaign scheduled","campaignId":"C1","status":"success"}`))
+	}))
+	defer termiiService.Close()
+
+	c, _ := termii.New(termii.WithAPIKey(termiiTestApiKey), termii.WithBaseURL(termiiService.URL))
+	lagos, err := time.LoadLocation("Africa/Lagos")
+	if err != nil {
+		t.Skip("tz database is not available")
+	}
+	at := time.Date(2021, time.June, 30, 5, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name, timezone     string
+		at                 time.Time
+		wantTime, wantZone string
+	}{
+		{"ScheduleAt is converted to Timezone", "Africa/Lagos", at, "30-06-2021 06:00", "Africa/Lagos"},
+		{"Timezone is taken from a named location", "", at.In(lagos), "30-06-2021 06:00", "Africa/Lagos"},
+		{"A Local time is sent in UTC", "", at.Local(), "30-06-2021 05:00", "UTC"},
+		{"A fixed offset is sent in UTC", "", at.In(time.FixedZone("WAT", 3600)), "30-06-2021 05:00", "UTC"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = termii.SendCampaignRequest{}
+			_, err := c.SendCampaign(termii.SendCampaignRequest{
+				CountryCode: "234",
+				SenderID:    "Acme",
+				Message:     "Our weekend sale starts now",
+				Channel:     termii.ChannelGeneric,
+				MessageType: "Plain",
+				PhonebookID: "f9c28de9-ab5a-4513-9c9f-338be8e1c390",
+				Timezone:    tt.timezone,
+				ScheduleAt:  tt.at,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantTime, received.ScheduleTime)
+			assert.Equal(t, tt.wantZone, received.Timezone)
+		})
+	}
+
+	t.Run("An unknown Timezone is rejected", func(t *testing.T) {
+		_, err := c.SendCampaign(termii.SendCampaignRequest{Timezone: "Mars/Olympus_Mons", ScheduleAt: at})
+		validationErr, ok := termii.AsValidationError(err)
+		assert.True(t, ok)
+		assert.Equal(t, "timezone", validationErr.Fields[0].Field)
+	})
+}
+
+func TestListCampaignsSuccess(t *testing.T) {
+	os.Setenv("TERMII_API_KEY", termiiTestApiKey)
+	var (
+		expectedResponse termii.ListCampaignsResponse
+	)
+
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		t.Run("URL and request method is as expected", func(t *testing.T) {
+			expectedURL := fmt.Sprintf("/api/sms/campaigns?api_key=%s", termiiTestApiKey)
+			assert.Equal(t, http.MethodGet, req.Method)
+			assert.Equal(t, expectedURL, req.RequestURI)
+		})
+
+		var resp termii.ListCampaignsResponse
+		fileToStruct(filepath.Join("testdata", "list_campaigns_response.json"), &resp)
+
+		w.WriteHeader(http.StatusOK)
+		bb, _ := json.Marshal(resp)
+		w.Write(bb)
+	}))
+	os.Setenv("TERMII_URL", termiiService.URL)
+
+	c := termii.NewClient()
+
+	resp, err := c.ListCampaigns()
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Response is as expected", func(t *testing.T) {
+		fileToStruct(filepath.Join("testdata", "list_campaigns_response.json"), &expectedResponse)
+		assert.Equal(t, expectedResponse, resp)
+	})
+}
+
+func TestGetCampaignHistorySuccess(t *testing.T) {
+	os.Setenv("TERMII_API_KEY", termiiTestApiKey)
+	var (
+		expectedResponse termii.CampaignHistoryResponse
+	)
+
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		t.Run("URL and request method is as expected", func(t *testing.T) {
+			expectedURL := fmt.Sprintf("/api/sms/campaigns/C783294824?api_key=%s&page=2", termiiTestApiKey)
+			assert.Equal(t, http.MethodGet, req.Method)
+			assert.Equal(t, expectedURL, req.RequestURI)
+		})
+
+		var resp termii.CampaignHistoryResponse
+		fileToStruct(filepath.Join("testdata", "campaign_history_response.json"), &resp)
+
+		w.WriteHeader(http.StatusOK)
+		bb, _ := json.Marshal(resp)
+		w.Write(bb)
+	}))
+	os.Setenv("TERMII_URL", termiiService.URL)
+
+	c := termii.NewClient()
+
+	resp, err := c.GetCampaignHistoryPage("C783294824", 2)
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Response is as expected", func(t *testing.T) {
+		fileToStruct(filepath.Join("testdata", "campaign_history_response.json"), &expectedResponse)
+		assert.Equal(t, expectedResponse, resp)
+	})
+}
+
+func TestSendVoiceTokenSuccess(t *testing.T) {
+	os.Setenv("TERMII_API_KEY", termiiTestApiKey)
+	var (
+		expectedTokenRequest termii.VoiceTokenRequest
+		receivedBody         termii.VoiceTokenRequest
+		req                  termii.VoiceTokenRequest
+		expectedResponse     termii.VoiceTokenResponse
+	)
+
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := json.NewDecoder(req.Body).Decode(&receivedBody); err != nil {
+			log.Printf("error in unmarshalling %+v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		t.Run("URL and request method is as expected", func(t *testing.T) {
+			expectedURL := "/api/sms/otp/send/voice"
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, expectedURL, req.RequestURI)
+		})
+
+		t.Run("Request is as expected", func(t *testing.T) {
+			fileToStruct(filepath.Join("testdata", "send_voice_token_request.json"), &expectedTokenRequest)
+			assert.Equal(t, expectedTokenRequest, receivedBody)
+		})
+
+		var resp termii.VoiceTokenResponse
+		fileToStruct(filepath.Join("testdata", "send_voice_token_response.json"), &resp)
+
+		w.WriteHeader(http.StatusOK)
+		bb, _ := json.Marshal(resp)
+		w.Write(bb)
+	}))
+	os.Setenv("TERMII_URL", termiiService.URL)
+	fileToStruct(filepath.Join("testdata", "send_voice_token_request.json"), &req)
+
+	c := termii.NewClient()
+
+	resp, err := c.SendVoiceToken(req)
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Response is as expected", func(t *testing.T) {
+		fileToStruct(filepath.Join("testdata", "send_voice_token_response.json"), &expectedResponse)
+		assert.Equal(t, expectedResponse, resp)
+	})
+}
+
+func TestSendVoiceCallSuccess(t *testing.T) {
+	os.Setenv("TERMII_API_KEY", termiiTestApiKey)
+	var (
+		expectedCallRequest termii.VoiceCallRequest
+		receivedBody        termii.VoiceCallRequest
+		req                 termii.VoiceCallRequest
+		expectedResponse    termii.VoiceCallResponse
+	)
+
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := json.NewDecoder(req.Body).Decode(&receivedBody); err != nil {
+			log.Printf("error in unmarshalling %+v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		t.Run("URL and request method is as expected", func(t *testing.T) {
+			expectedURL := "/api/sms/otp/call"
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, expectedURL, req.RequestURI)
+		})
+
+		t.Run("Request is as expected", func(t *testing.T) {
+			fileToStruct(filepath.Join("testdata", "send_voice_call_request.json"), &expectedCallRequest)
+			assert.Equal(t, expectedCallRequest, receivedBody)
+		})
+
+		var resp termii.VoiceCallResponse
+		fileToStruct(filepath.Join("testdata", "send_voice_call_response.json"), &resp)
+
+		w.WriteHeader(http.StatusOK)
+		bb, _ := json.Marshal(resp)
+		w.Write(bb)
+	}))
+	os.Setenv("TERMII_URL", termiiService.URL)
+	fileToStruct(filepath.Join("testdata", "send_voice_call_request.json"), &req)
+
+	c := termii.NewClient()
+
+	resp, err := c.SendVoiceCall(req)
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Response is as expected", func(t *testing.T) {
+		fileToStruct(filepath.Join("testdata", "send_voice_call_response.json"), &expectedResponse)
+		assert.Equal(t, expectedResponse, resp)
+	})
+}
+
+func TestSendVoiceValidation(t *testing.T) {
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		t.Error("invalid request should not reach termii")
+	}))
+	defer termiiService.Close()
+	os.Setenv("TERMII_URL", termiiService.URL)
+
+	c := termii.NewClient()
+
+	tokenTable := []termii.VoiceTokenRequest{
+		{PhoneNumber: "2347880234567", PinAttempts: 3, PinTimeToLive: 5, PinLength: 3},
+		{PhoneNumber: "2347880234567", PinAttempts: 3, PinTimeToLive: 5, PinLength: 9},
+		{PhoneNumber: "2347880234567", PinAttempts: 0, PinTimeToLive: 5, PinLength: 6},
+		{PhoneNumber: "2347880234567", PinAttempts: 3, PinTimeToLive: 61, PinLength: 6},
+		{PinAttempts: 3, PinTimeToLive: 5, PinLength: 6},
+	}
+	for i, req := range tokenTable {
+		_, err := c.SendVoiceToken(req)
+		t.Run(fmt.Sprintf("Voice token %d - Error is returned", i), func(t *testing.T) {
+			assert.Error(t, err)
+		})
+	}
+
+	callTable := []termii.VoiceCallRequest{
+		{PhoneNumber: "2347880234567", Code: 999},
+		{PhoneNumber: "2347880234567", Code: 123456789},
+		{Code: 4725},
+	}
+	for i, req := range callTable {
+		_, err := c.SendVoiceCall(req)
+		t.Run(fmt.Sprintf("Voice call %d - Error is returned", i), func(t *testing.T) {
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSendEmailTokenSuccess(t *testing.T) {
+	os.Setenv("TERMII_API_KEY", termiiTestApiKey)
+	var (
+		expectedTokenRequest termii.EmailTokenRequest
+		receivedBody         termii.EmailTokenRequest
+		req                  termii.EmailTokenRequest
+		expectedResponse     termii.EmailTokenResponse
+	)
+
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err := json.NewDecoder(req.Body).Decode(&receivedBody); err != nil {
+			log.Printf("error in unmarshalling %+v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		t.Run("URL and request method is as expected", func(t *testing.T) {
+			expectedURL := "/api/email/otp/send"
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, expectedURL, req.RequestURI)
+		})
+
+		t.Run("Request is as expected", func(t *testing.T) {
+			fileToStruct(filepath.Join("testdata", "send_email_token_request.json"), &expectedTokenRequest)
+			assert.Equal(t, expectedTokenRequest, receivedBody)
+		})
+
+		var resp termii.EmailTokenResponse
+		fileToStruct(filepath.Join("testdata", "send_email_token_response.json"), &resp)
+
+		w.WriteHeader(http.StatusOK)
+		bb, _ := json.Marshal(resp)
+		w.Write(bb)
+	}))
+	os.Setenv("TERMII_URL", termiiService.URL)
+	fileToStruct(filepath.Join("testdata", "send_email_token_request.json"), &req)
+
+	c := termii.NewClient()
+
+	resp, err := c.SendEmailToken(req)
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Response is as expected", func(t *testing.T) {
+		fileToStruct(filepath.Join("testdata", "send_email_token_response.json"), &expectedResponse)
+		assert.Equal(t, expectedResponse, resp)
+	})
+}
+
+func TestVerifyEmailChannelTokenSuccess(t *testing.T) {
+	os.Setenv("TERMII_API_KEY", termiiTestApiKey)
+	var (
+		expectedTokenRequest termii.SendTokenRequest
+		receivedSendBody     termii.SendTokenRequest
+		receivedVerifyBody   termii.VerifyTokenRequest
+		req                  termii.SendTokenRequest
+		expectedResponse     termii.VerifyTokenResponse
+	)
+	pinID := "c8dcd048-5e7f-4347-8c89-4470c3af0b73"
+
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.RequestURI {
+		case "/api/sms/otp/send":
+			json.NewDecoder(req.Body).Decode(&receivedSendBody)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(fmt.Sprintf(`{"pinId":"%s","to":"jane@example.com","smsStatus":"Message Sent"}`, pinID)))
+		case "/api/sms/otp/verify":
+			json.NewDecoder(req.Body).Decode(&receivedVerifyBody)
+			var resp termii.VerifyTokenResponse
+			fileToStruct(filepath.Join("testdata", "verify_email_token_response.json"), &resp)
+
+			w.WriteHeader(http.StatusOK)
+			bb, _ := json.Marshal(resp)
+			w.Write(bb)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	os.Setenv("TERMII_URL", termiiService.URL)
+	fileToStruct(filepath.Join("testdata", "send_email_channel_token_request.json"), &req)
+
+	c := termii.NewClient()
+
+	sent, err := c.SendToken(req)
+	t.Run("No error is returned when sending", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Email channel request is as expected", func(t *testing.T) {
+		fileToStruct(filepath.Join("testdata", "send_email_channel_token_request.json"), &expectedTokenRequest)
+		assert.Equal(t, expectedTokenRequest, receivedSendBody)
+	})
+
+	resp, err := c.VerifyToken(termii.VerifyTokenRequest{PinID: sent.PinID, Pin: "195558"})
+	t.Run("No error is returned when verifying", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Pin id issued over email is verified", func(t *testing.T) {
+		assert.Equal(t, pinID, receivedVerifyBody.PinID)
+		fileToStruct(filepath.Join("testdata", "verify_email_token_response.json"), &expectedResponse)
+		assert.Equal(t, expectedResponse, resp)
+	})
+}
+
+func TestGetMessageStatusSuccess(t *testing.T) {
+	os.Setenv("TERMII_API_KEY", termiiTestApiKey)
+	var (
+		expectedResponse []termii.HistoryResponse
+	)
+
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		t.Run("URL and request method is as expected", func(t *testing.T) {
+			expectedURL := fmt.Sprintf("/api/sms/inbox?api_key=%s&message_id=5508751839629937023", termiiTestApiKey)
+			assert.Equal(t, http.MethodGet, req.Method)
+			assert.Equal(t, expectedURL, req.RequestURI)
+		})
+
+		var resp []termii.HistoryResponse
+		fileToStruct(filepath.Join("testdata", "get_message_status_response.json"), &resp)
+
+		w.WriteHeader(http.StatusOK)
+		bb, _ := json.Marshal(resp)
+		w.Write(bb)
+	}))
+	os.Setenv("TERMII_URL", termiiService.URL)
+
+	c := termii.NewClient()
+	resp, err := c.GetMessageStatus("5508751839629937023")
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Response is as expected", func(t *testing.T) {
+		fileToStruct(filepath.Join("testdata", "get_message_status_response.json"), &expectedResponse)
+		assert.Equal(t, expectedResponse[0], resp)
+		assert.True(t, resp.IsTerminal())
+	})
+}
+
+func TestWaitForDelivery(t *testing.T) {
+	os.Setenv("TERMII_API_KEY", termiiTestApiKey)
+
+	statuses := []string{"", "Sent", "Delivered"}
+	polls := 0
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		status := statuses[polls]
+		polls++
+
+		w.WriteHeader(http.StatusOK)
+		if status == "" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		fmt.Fprintf(w, `[{"message_id":"5508751839629937023","status":%q}]`, status)
+	}))
+	defer termiiService.Close()
+	os.Setenv("TERMII_URL", termiiService.URL)
+
+	c := termii.NewClient()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := c.WaitForDelivery(ctx, "5508751839629937023", 10*time.Millisecond)
+	t.Run("No error is returned", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	t.Run("Polling stops at terminal status", func(t *testing.T) {
+		assert.Equal(t, 3, polls)
+		assert.Equal(t, termii.MessageStatusDelivered, resp.Status)
+	})
+
+	statuses = append(statuses, "")
+	_, err = c.GetMessageStatus("5508751839629937023")
+	t.Run("Unknown message is not found", func(t *testing.T) {
+		assert.True(t, errors.Is(err, termii.ErrMessageNotFound))
+	})
+
+	statuses, polls = []string{"Message Sent", termii.MessageStatusDNDActive}, 0
+	resp, err = c.WaitForDelivery(ctx, "5508751839629937023", 10*time.Millisecond)
+	t.Run("Polling stops when the number has DND active", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Equal(t, 2, polls)
+		assert.Equal(t, termii.MessageStatusDNDActive, resp.Status)
+	})
+}
+
+func TestMessageStatusIsTerminal(t *testing.T) {
+	tests := []struct {
+		status   string
+		terminal bool
+	}{
+		{status: "Delivered", terminal: true},
+		{status: "Message Failed", terminal: true},
+		{status: "rejected", terminal: true},
+		{status: "Expired", terminal: true},
+		{status: "DND Active on Phone Number", terminal: true},
+		{status: " dnd active on phone number ", terminal: true},
+		{status: "Message Sent", terminal: false},
+		{status: "Pending", terminal: false},
+		{status: "", terminal: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("Status %q", tt.status), func(t *testing.T) {
+			assert.Equal(t, tt.terminal, termii.HistoryResponse{Status: tt.status}.IsTerminal())
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package termiimock
+
+import (
+	"context"
+
+	termii "github.com/Uchencho/go-termii"
+)
+
+// methods of termii.Messenger
+
+// SendMessage records the call and returns the response scripted for SendMessage
+func (m *Mock) SendMessage(req termii.SendMessageRequest) (termii.SendMessageResponse, error) {
+	return m.SendMessageWithContext(context.Background(), req)
+}
+
+// SendMessageWithContext is like SendMessage, the call is recorded as SendMessage
+func (m *Mock) SendMessageWithContext(ctx context.Context, req termii.SendMessageRequest) (termii.SendMessageResponse, error) {
+	var resp termii.SendMessageResponse
+	err := m.call("SendMessage", &resp, req)
+	return resp, err
+}
+
+// SendBulkMessage records the call and returns the response scripted for SendBulkMessage
+func (m *Mock) SendBulkMessage(req termii.BulkMessageRequest) (termii.BulkMessageResult, error) {
+	return m.SendBulkMessageWithContext(context.Background(), req)
+}
+
+// SendBulkMessageWithContext is like SendBulkMessage, the call is recorded as SendBulkMessage
+func (m *Mock) SendBulkMessageWithContext(ctx context.Context, req termii.BulkMessageRequest) (termii.BulkMessageResult, error) {
+	var resp termii.BulkMessageResult
+	err := m.call("SendBulkMessage", &resp, req)
+	return resp, err
+}
+
+// SendAutoGeneratedMessage records the call and returns the response scripted for SendAutoGeneratedMessage
+func (m *Mock) SendAutoGeneratedMessage(req termii.AutoGeneratedMessageRequest) (termii.AutoGeneratedMessageResponse, error) {
+	return m.SendAutoGeneratedMessageWithContext(context.Background(), req)
+}
+
+// SendAutoGeneratedMessageWithContext is like SendAutoGeneratedMessage, the call is recorded as SendAutoGeneratedMessage
+func (m *Mock) SendAutoGeneratedMessageWithContext(ctx context.Context, req termii.AutoGeneratedMessageRequest) (termii.AutoGeneratedMessageResponse, error) {
+	var resp termii.AutoGeneratedMessageResponse
+	err := m.call("SendAutoGeneratedMessage", &resp, req)
+	return resp, err
+}
+
+// SetDeviceTemplate records the call and returns the response scripted for SetDeviceTemplate
+func (m *Mock) SetDeviceTemplate(req termii.TemplateRequest) ([]termii.TemplateResponse, error) {
+	return m.SetDeviceTemplateWithContext(context.Background(), req)
+}
+
+// SetDeviceTemplateWithContext is like SetDeviceTemplate, the call is recorded as SetDeviceTemplate
+func (m *Mock) SetDeviceTemplateWithContext(ctx context.Context, req termii.TemplateRequest) ([]termii.TemplateResponse, error) {
+	var resp []termii.TemplateResponse
+	err := m.call("SetDeviceTemplate", &resp, req)
+	return resp, err
+}
+
+// methods of termii.TokenService
+
+// SendToken records the call and returns the response scripted for SendToken
+func (m *Mock) SendToken(req termii.SendTokenRequest) (termii.SendTokenResponse, error) {
+	return m.SendTokenWithContext(context.Background(), req)
+}
+
+// SendTokenWithContext is like SendToken, the call is recorded as SendToken
+func (m *Mock) SendTokenWithContext(ctx context.Context, req termii.SendTokenRequest) (termii.SendTokenResponse, error) {
+	var resp termii.SendTokenResponse
+	err := m.call("SendToken", &resp, req)
+	return resp, err
+}
+
+// VerifyToken records the call and returns the response scripted for VerifyToken
+func (m *Mock) VerifyToken(req termii.VerifyTokenRequest) (termii.VerifyTokenResponse, error) {
+	return m.VerifyTokenWithContext(context.Background(), req)
+}
+
+// VerifyTokenWithContext is like VerifyToken, the call is recorded as VerifyToken
+func (m *Mock) VerifyTokenWithContext(ctx context.Context, req termii.VerifyTokenRequest) (termii.VerifyTokenResponse, error) {
+	var resp termii.VerifyTokenResponse
+	err := m.call("VerifyToken", &resp, req)
+	return resp, err
+}
+
+// GetInAppToken records the call and returns the response scripted for GetInAppToken
+func (m *Mock) GetInAppToken(req termii.GenerateTokenRequest) (termii.GenerateTokenResponse, error) {
+	return m.GetInAppTokenWithContext(context.Background(), req)
+}
+
+// GetInAppTokenWithContext is like GetInAppToken, the call is recorded as GetInAppToken
+func (m *Mock) GetInAppTokenWithContext(ctx context.Context, req termii.GenerateTokenRequest) (termii.GenerateTokenResponse, error) {
+	var resp termii.GenerateTokenResponse
+	err := m.call("GetInAppToken", &resp, req)
+	return resp, err
+}
+
+// SendVoiceToken records the call and returns the response scripted for SendVoiceToken
+func (m *Mock) SendVoiceToken(req termii.VoiceTokenRequest) (termii.VoiceTokenResponse, error) {
+	return m.SendVoiceTokenWithContext(context.Background(), req)
+}
+
+// SendVoiceTokenWithContext is like SendVoiceToken, the call is recorded as SendVoiceToken
+func (m *Mock) SendVoiceTokenWithContext(ctx context.Context, req termii.VoiceTokenRequest) (termii.VoiceTokenResponse, error) {
+	var resp termii.VoiceTokenResponse
+	err := m.call("SendVoiceToken", &resp, req)
+	return resp, err
+}
+
+// SendVoiceCall records the call and returns the response scripted for SendVoiceCall
+func (m *Mock) SendVoiceCall(req termii.VoiceCallRequest) (termii.VoiceCallResponse, error) {
+	return m.SendVoiceCallWithContext(context.Background(), req)
+}
+
+// SendVoiceCallWithContext is like SendVoiceCall, the call is recorded as SendVoiceCall
+func (m *Mock) SendVoiceCallWithContext(ctx context.Context, req termii.VoiceCallRequest) (termii.VoiceCallResponse, error) {
+	var resp termii.VoiceCallResponse
+	err := m.call("SendVoiceCall", &resp, req)
+	return resp, err
+}
+
+// SendEmailToken records the call and returns the response scripted for SendEmailToken
+func (m *Mock) SendEmailToken(req termii.EmailTokenRequest) (termii.EmailTokenResponse, error) {
+	return m.SendEmailTokenWithContext(context.Background(), req)
+}
+
+// SendEmailTokenWithContext is like SendEmailToken, the call is recorded as SendEmailToken
+func (m *Mock) SendEmailTokenWithContext(ctx context.Context, req termii.EmailTokenRequest) (termii.EmailTokenResponse, error) {
+	var resp termii.EmailTokenResponse
+	err := m.call("SendEmailToken", &resp, req)
+	return resp, err
+}
+
+// methods of termii.InsightService
+
+// GetBalance records the call and returns the response scripted for GetBalance
+func (m *Mock) GetBalance() (termii.GetBalanceResponse, error) {
+	return m.GetBalanceWithContext(context.Background())
+}
+
+// GetBalanceWithContext is like GetBalance, the call is recorded as GetBalance
+func (m *Mock) GetBalanceWithContext(ctx context.Context) (termii.GetBalanceResponse, error) {
+	var resp termii.GetBalanceResponse
+	err := m.call("GetBalance", &resp)
+	return resp, err
+}
+
+// VerifyNumber records the call and returns the response scripted for VerifyNumber
+func (m *Mock) VerifyNumber(req termii.VerifyNumberRequest) (termii.VerifyNumberResponse, error) {
+	return m.VerifyNumberWithContext(context.Background(), req)
+}
+
+// VerifyNumberWithContext is like VerifyNumber, the call is recorded as VerifyNumber
+func (m *Mock) VerifyNumberWithContext(ctx context.Context, req termii.VerifyNumberRequest) (termii.VerifyNumberResponse, error) {
+	var resp termii.VerifyNumberResponse
+	err := m.call("VerifyNumber", &resp, req)
+	return resp, err
+}
+
+// GetStatus records the call and returns the response scripted for GetStatus
+func (m *Mock) GetStatus(req termii.StatusRequest) (termii.StatusResponse, error) {
+	return m.GetStatusWithContext(context.Background(), req)
+}
+
+// GetStatusWithContext is like GetStatus, the call is recorded as GetStatus
+func (m *Mock) GetStatusWithContext(ctx context.Context, req termii.StatusRequest) (termii.StatusResponse, error) {
+	var resp termii.StatusResponse
+	err := m.call("GetStatus", &resp, req)
+	return resp, err
+}
+
+// GetHistory records the call and returns the response scripted for GetHistory
+func (m *Mock) GetHistory() ([]termii.HistoryResponse, error) {
+	return m.GetHistoryWithContext(context.Background())
+}
+
+// GetHistoryWithContext is like GetHistory, the call is recorded as GetHistory
+func (m *Mock) GetHistoryWithContext(ctx context.Context) ([]termii.HistoryResponse, error) {
+	var resp []termii.HistoryResponse
+	err := m.call("GetHistory", &resp)
+	return resp, err
+}
+
+// GetMessageStatus records the call and returns the response scripted for GetMessageStatus
+func (m *Mock) GetMessageStatus(messageID string) (termii.HistoryResponse, error) {
+	return m.GetMessageStatusWithContext(context.Background(), messageID)
+}
+
+// GetMessageStatusWithContext is like GetMessageStatus, the call is recorded as GetMessageStatus
+func (m *Mock) GetMessageStatusWithContext(ctx context.Context, messageID string) (termii.HistoryResponse, error) {
+	var resp termii.HistoryResponse
+	err := m.call("GetMessageStatus", &resp, messageID)
+	return resp, err
+}
+
+// methods of termii.SenderIDService
+
+// FetchSenderID records the call and returns the response scripted for FetchSenderID
+func (m *Mock) FetchSenderID() (termii.FetchSenderIdResponse, error) {
+	return m.FetchSenderIDWithContext(context.Background())
+}
+
+// FetchSenderIDWithContext is like FetchSenderID, the call is recorded as FetchSenderID
+func (m *Mock) FetchSenderIDWithContext(ctx context.Context) (termii.FetchSenderIdResponse, error) {
+	var resp termii.FetchSenderIdResponse
+	err := m.call("FetchSenderID", &resp)
+	return resp, err
+}
+
+// FetchSenderIDPage records the call and returns the response scripted for FetchSenderIDPage
+func (m *Mock) FetchSenderIDPage(page int) (termii.FetchSenderIdResponse, error) {
+	return m.FetchSenderIDPageWithContext(context.Background(), page)
+}
+
+// FetchSenderIDPageWithContext is like FetchSenderIDPage, the call is recorded as FetchSenderIDPage
+func (m *Mock) FetchSenderIDPageWithContext(ctx context.Context, page int) (termii.FetchSenderIdResponse, error) {
+	var resp termii.FetchSenderIdResponse
+	err := m.call("FetchSenderIDPage", &resp, page)
+	return resp, err
+}
+
+// RegisterSender records the call and returns the response scripted for RegisterSender
+func (m *Mock) RegisterSender(req termii.RegisterSenderIdRequest) (termii.RegisterSenderResponse, error) {
+	return m.RegisterSenderWithContext(context.Background(), req)
+}
+
+// RegisterSenderWithContext is like RegisterSender, the call is recorded as RegisterSender
+func (m *Mock) RegisterSenderWithContext(ctx context.Context, req termii.RegisterSenderIdRequest) (termii.RegisterSenderResponse, error) {
+	var resp termii.RegisterSenderResponse
+	err := m.call("RegisterSender", &resp, req)
+	return resp, err
+}
@@ -0,0 +1,173 @@
+// Package termiimock provides a recording mock of the termii api for unit tests.
+//
+// A Mock satisfies termii.API. Every call is recorded under the name of the method, a call to
+// SendMessageWithContext is recorded as SendMessage, and answered with the responses scripted for it:
+//
+//	m := termiimock.New()
+//	m.On("SendToken", termii.SendTokenResponse{PinID: "29ae67c2"}, nil)
+//	m.On("VerifyToken", termii.VerifyTokenResponse{Verified: "True"}, nil)
+//
+//	svc := NewOTPService(m)
+//	...
+//	m.AssertCalled(t, "SendToken", expectedRequest)
+package termiimock
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	termii "github.com/Uchencho/go-termii"
+	"github.com/pkg/errors"
+)
+
+// ErrUnexpectedCall is returned by calls to methods without a scripted response
+var ErrUnexpectedCall = errors.New("termiimock - unexpected call")
+
+var _ termii.API = (*Mock)(nil)
+
+// Call is a representation of a recorded call, Args are the arguments of the method after the context
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// HandlerFunc computes the response of a call from its arguments
+type HandlerFunc func(args ...interface{}) (interface{}, error)
+
+// result is a representation of a scripted response
+type result struct {
+	handle HandlerFunc
+}
+
+// Mock is a recording mock of termii.API. It is safe for concurrent use.
+type Mock struct {
+	mu        sync.Mutex
+	calls     []Call
+	responses map[string][]result
+}
+
+// New creates a Mock without any scripted responses
+func New() *Mock {
+	return &Mock{responses: make(map[string][]result)}
+}
+
+// On scripts the response of the next call to method. Responses scripted for a method are returned
+// in order, the last one is returned again once the others are used. resp must be of the type returned
+// by the method, or nil for its zero value.
+func (m *Mock) On(method string, resp interface{}, err error) *Mock {
+	return m.Handle(method, func(args ...interface{}) (interface{}, error) {
+		return resp, err
+	})
+}
+
+// Handle scripts fn to compute the response of the next call to method, in the same order as On
+func (m *Mock) Handle(method string, fn HandlerFunc) *Mock {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.responses[method] = append(m.responses[method], result{handle: fn})
+	return m
+}
+
+// Calls returns the recorded calls to method, or every recorded call if method is empty
+func (m *Mock) Calls(method string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var calls []Call
+	for _, c := range m.calls {
+		if method == "" || c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Reset forgets the recorded calls and scripted responses
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+	m.responses = make(map[string][]result)
+}
+
+// AssertCalled asserts that method was called with args. Without args, any call to method is accepted.
+func (m *Mock) AssertCalled(t testing.TB, method string, args ...interface{}) bool {
+	t.Helper()
+	calls := m.Calls(method)
+	for _, c := range calls {
+		if len(args) == 0 || reflect.DeepEqual(c.Args, args) {
+			return true
+		}
+	}
+	if len(calls) == 0 {
+		t.Errorf("termiimock - expected a call to %s, got none", method)
+	} else {
+		t.Errorf("termiimock - expected a call to %s with %s, got %s", method, formatArgs(args), formatCalls(calls))
+	}
+	return false
+}
+
+// AssertNotCalled asserts that method was never called
+func (m *Mock) AssertNotCalled(t testing.TB, method string) bool {
+	t.Helper()
+	if calls := m.Calls(method); len(calls) > 0 {
+		t.Errorf("termiimock - expected no call to %s, got %s", method, formatCalls(calls))
+		return false
+	}
+	return true
+}
+
+// AssertNumberOfCalls asserts that method was called n times
+func (m *Mock) AssertNumberOfCalls(t testing.TB, method string, n int) bool {
+	t.Helper()
+	if calls := m.Calls(method); len(calls) != n {
+		t.Errorf("termiimock - expected %d call(s) to %s, got %d", n, method, len(calls))
+		return false
+	}
+	return true
+}
+
+// call records a call to method and stores its scripted response in out, a pointer to the return type of method
+func (m *Mock) call(method string, out interface{}, args ...interface{}) error {
+	m.mu.Lock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+	scripted := m.responses[method]
+	var next result
+	if len(scripted) > 0 {
+		next = scripted[0]
+		if len(scripted) > 1 {
+			m.responses[method] = scripted[1:]
+		}
+	}
+	m.mu.Unlock()
+
+	if next.handle == nil {
+		return errors.Wrapf(ErrUnexpectedCall, "no response scripted for %s", method)
+	}
+
+	resp, err := next.handle(args...)
+	if resp != nil {
+		dst := reflect.ValueOf(out).Elem()
+		src := reflect.ValueOf(resp)
+		if !src.Type().AssignableTo(dst.Type()) {
+			panic(fmt.Sprintf("termiimock - response scripted for %s is a %s, expected a %s", method, src.Type(), dst.Type()))
+		}
+		dst.Set(src)
+	}
+	return err
+}
+
+func formatArgs(args []interface{}) string {
+	return fmt.Sprintf("%+v", args)
+}
+
+func formatCalls(calls []Call) string {
+	formatted := make([]string, len(calls))
+	for i, c := range calls {
+		formatted[i] = formatArgs(c.Args)
+	}
+	return strings.Join(formatted, ", ")
+}
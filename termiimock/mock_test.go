@@ -0,0 +1,107 @@
+package termiimock_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	termii "github.com/Uchencho/go-termii"
+	"github.com/Uchencho/go-termii/termiimock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// otpService is a representation of code under test depending on a subset of the api
+type otpService struct {
+	tokens termii.TokenService
+}
+
+func (s otpService) verify(ctx context.Context, pinID, pin string) (bool, error) {
+	resp, err := s.tokens.VerifyTokenWithContext(ctx, termii.VerifyTokenRequest{PinID: pinID, Pin: pin})
+	if err != nil {
+		return false, err
+	}
+	return fmt.Sprint(resp.Verified) == "True", nil
+}
+
+// recorder captures the failure reported by an assertion instead of failing the test
+type recorder struct {
+	testing.TB
+	failure string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failure = fmt.Sprintf(format, args...)
+}
+
+func TestScriptedResponses(t *testing.T) {
+	m := termiimock.New()
+	m.On("VerifyToken", termii.VerifyTokenResponse{Verified: "False"}, nil).
+		On("VerifyToken", termii.VerifyTokenResponse{Verified: "True"}, nil)
+
+	svc := otpService{tokens: m}
+	first, err := svc.verify(context.Background(), "29ae67c2", "000000")
+	t.Run("First scripted response is returned first", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.False(t, first)
+	})
+
+	for i := 0; i < 2; i++ {
+		verified, err := svc.verify(context.Background(), "29ae67c2", "195558")
+		t.Run("Last scripted response is repeated", func(t *testing.T) {
+			assert.NoError(t, err)
+			assert.True(t, verified)
+		})
+	}
+
+	t.Run("Calls are recorded without their context", func(t *testing.T) {
+		m.AssertNumberOfCalls(t, "VerifyToken", 3)
+		m.AssertCalled(t, "VerifyToken", termii.VerifyTokenRequest{PinID: "29ae67c2", Pin: "195558"})
+		m.AssertNotCalled(t, "SendToken")
+	})
+
+	_, err = m.SendToken(termii.SendTokenRequest{To: "2348109077743"})
+	t.Run("Unscripted call returns an error", func(t *testing.T) {
+		assert.True(t, errors.Is(err, termiimock.ErrUnexpectedCall))
+		assert.Len(t, m.Calls(""), 4)
+	})
+}
+
+func TestHandlerAndErrors(t *testing.T) {
+	m := termiimock.New()
+	m.Handle("GetMessageStatus", func(args ...interface{}) (interface{}, error) {
+		return termii.HistoryResponse{MessageID: args[0].(string), Status: termii.MessageStatusDelivered}, nil
+	})
+	m.On("GetBalance", nil, &termii.APIError{StatusCode: 401})
+
+	var api termii.API = m
+	status, err := api.GetMessageStatus("9122821270554876574")
+	t.Run("Response is computed from the arguments", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Equal(t, "9122821270554876574", status.MessageID)
+	})
+
+	_, err = api.GetBalance()
+	t.Run("Scripted error is returned", func(t *testing.T) {
+		assert.True(t, termii.IsUnauthorized(err))
+	})
+
+	t.Run("Response of the wrong type panics", func(t *testing.T) {
+		m.On("SendMessage", termii.SendTokenResponse{}, nil)
+		assert.Panics(t, func() { m.SendMessage(termii.SendMessageRequest{}) })
+	})
+
+	t.Run("Failed assertion is reported", func(t *testing.T) {
+		rec := &recorder{TB: t}
+		assert.False(t, m.AssertCalled(rec, "GetMessageStatus", "another-id"))
+		assert.Contains(t, rec.failure, "another-id")
+	})
+
+	m.Reset()
+	t.Run("Reset forgets calls", func(t *testing.T) {
+		assert.Empty(t, m.Calls(""))
+	})
+}
@@ -0,0 +1,252 @@
+// Package termiiotel records the requests made by a termii client as OpenTelemetry spans and metrics.
+//
+// It lives in a module of its own so that the client does not depend on OpenTelemetry.
+//
+//	inst, err := termiiotel.New()
+//	client, err := termii.New(termii.WithAPIKey(apiKey), termii.WithBaseURL(baseURL), termii.WithInstrumentation(inst))
+package termiiotel
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	termii "github.com/Uchencho/go-termii"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the spans and metrics recorded by the package
+const ScopeName = "github.com/Uchencho/go-termii/termiiotel"
+
+// Attribute keys recorded on spans and metrics
+const (
+	KeyEndpoint   = attribute.Key("termii.endpoint")
+	KeyOutcome    = attribute.Key("termii.outcome")
+	KeyCode       = attribute.Key("termii.code")
+	KeyRetryCount = attribute.Key("termii.retry_count")
+	KeyChannel    = attribute.Key("termii.channel")
+	KeyVerified   = attribute.Key("termii.verified")
+	KeyCurrency   = attribute.Key("termii.currency")
+
+	keyMethod     = attribute.Key("http.request.method")
+	keyStatusCode = attribute.Key("http.response.status_code")
+	keyErrorType  = attribute.Key("error.type")
+)
+
+// Outcomes of a request, recorded as KeyOutcome
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+)
+
+// Metrics recorded by the package
+const (
+	MetricRequestDuration   = "termii.client.request.duration"
+	MetricRequests          = "termii.client.requests"
+	MetricMessagesSent      = "termii.messages.sent"
+	MetricVerifications     = "termii.otp.verifications"
+	MetricVerifySuccessRate = "termii.otp.verify.success_ratio"
+	MetricWalletBalance     = "termii.wallet.balance"
+)
+
+// durationBuckets are the boundaries, in seconds, of the request duration histogram
+var durationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10, 30}
+
+// Option is a representation of a configuration of the instrumentation
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithTracerProvider records spans with tp instead of the global tracer provider
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider records metrics with mp instead of the global meter provider
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = mp
+	}
+}
+
+// Instrumentation records a span per request made by a client, and metrics of the requests, messages sent,
+// pin verifications and wallet balance. It implements termii.Instrumentation and may be shared by clients.
+type Instrumentation struct {
+	tracer trace.Tracer
+
+	duration      metric.Float64Histogram
+	requests      metric.Int64Counter
+	messages      metric.Int64Counter
+	verifications metric.Int64Counter
+	ratio         metric.Float64ObservableGauge
+	balance       metric.Float64ObservableGauge
+
+	mu       sync.Mutex
+	verified int64
+	verifies int64
+	balances map[string]float64
+}
+
+var _ termii.Instrumentation = (*Instrumentation)(nil)
+
+// New creates an instrumentation recording to the global tracer and meter providers unless overridden by opts
+func New(opts ...Option) (*Instrumentation, error) {
+	cfg := config{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.tracerProvider == nil {
+		cfg.tracerProvider = otel.GetTracerProvider()
+	}
+	if cfg.meterProvider == nil {
+		cfg.meterProvider = otel.GetMeterProvider()
+	}
+
+	inst := &Instrumentation{
+		tracer:   cfg.tracerProvider.Tracer(ScopeName),
+		balances: make(map[string]float64),
+	}
+	meter := cfg.meterProvider.Meter(ScopeName)
+
+	var err error
+	if inst.duration, err = meter.Float64Histogram(MetricRequestDuration,
+		metric.WithDescription("Duration of requests to termii, including retries"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(durationBuckets...),
+	); err != nil {
+		return nil, fmt.Errorf("termiiotel - unable to create %s: %w", MetricRequestDuration, err)
+	}
+	if inst.requests, err = meter.Int64Counter(MetricRequests,
+		metric.WithDescription("Requests made to termii by endpoint and outcome"),
+		metric.WithUnit("{request}"),
+	); err != nil {
+		return nil, fmt.Errorf("termiiotel - unable to create %s: %w", MetricRequests, err)
+	}
+	if inst.messages, err = meter.Int64Counter(MetricMessagesSent,
+		metric.WithDescription("Messages accepted by termii by channel"),
+		metric.WithUnit("{message}"),
+	); err != nil {
+		return nil, fmt.Errorf("termiiotel - unable to create %s: %w", MetricMessagesSent, err)
+	}
+	if inst.verifications, err = meter.Int64Counter(MetricVerifications,
+		metric.WithDescription("Pin verifications by outcome"),
+		metric.WithUnit("{verification}"),
+	); err != nil {
+		return nil, fmt.Errorf("termiiotel - unable to create %s: %w", MetricVerifications, err)
+	}
+
+	if inst.ratio, err = meter.Float64ObservableGauge(MetricVerifySuccessRate,
+		metric.WithDescription("Fraction of pin verifications that succeeded"),
+		metric.WithUnit("1"),
+	); err != nil {
+		return nil, fmt.Errorf("termiiotel - unable to create %s: %w", MetricVerifySuccessRate, err)
+	}
+	if inst.balance, err = meter.Float64ObservableGauge(MetricWalletBalance,
+		metric.WithDescription("Last wallet balance reported by termii"),
+	); err != nil {
+		return nil, fmt.Errorf("termiiotel - unable to create %s: %w", MetricWalletBalance, err)
+	}
+	if _, err := meter.RegisterCallback(inst.observe, inst.ratio, inst.balance); err != nil {
+		return nil, fmt.Errorf("termiiotel - unable to register callback: %w", err)
+	}
+	return inst, nil
+}
+
+// StartCall starts a client span for the request, ended with its status code, termii code and retry count
+func (inst *Instrumentation) StartCall(ctx context.Context, call termii.CallInfo) (context.Context, func(termii.CallResult)) {
+	ctx, span := inst.tracer.Start(ctx, fmt.Sprintf("%s %s", call.Method, call.Endpoint),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(keyMethod.String(call.Method), KeyEndpoint.String(string(call.Endpoint))),
+	)
+
+	return ctx, func(result termii.CallResult) {
+		defer span.End()
+
+		outcome := OutcomeSuccess
+		if result.Err != nil {
+			outcome = OutcomeError
+		}
+		attrs := []attribute.KeyValue{KeyRetryCount.Int(retries(result.Attempts))}
+		if result.StatusCode > 0 {
+			attrs = append(attrs, keyStatusCode.Int(result.StatusCode))
+		}
+		if result.Code != "" {
+			attrs = append(attrs, KeyCode.String(result.Code))
+		}
+		if result.Err != nil {
+			attrs = append(attrs, keyErrorType.String(errorType(result)))
+			span.SetStatus(codes.Error, result.Error)
+		}
+		span.SetAttributes(attrs...)
+
+		set := metric.WithAttributes(
+			keyMethod.String(call.Method),
+			KeyEndpoint.String(string(call.Endpoint)),
+			KeyOutcome.String(outcome),
+		)
+		inst.duration.Record(ctx, result.Duration.Seconds(), set)
+		inst.requests.Add(ctx, 1, set)
+	}
+}
+
+// MessagesSent counts the messages accepted by termii over channel
+func (inst *Instrumentation) MessagesSent(ctx context.Context, channel string, count int) {
+	inst.messages.Add(ctx, int64(count), metric.WithAttributes(KeyChannel.String(channel)))
+}
+
+// TokenVerified counts a pin verification and updates the verify success ratio
+func (inst *Instrumentation) TokenVerified(ctx context.Context, verified bool) {
+	inst.mu.Lock()
+	inst.verifies++
+	if verified {
+		inst.verified++
+	}
+	inst.mu.Unlock()
+
+	inst.verifications.Add(ctx, 1, metric.WithAttributes(KeyVerified.Bool(verified)))
+}
+
+// BalanceObserved records the balance of the wallet in currency
+func (inst *Instrumentation) BalanceObserved(ctx context.Context, balance float64, currency string) {
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+	inst.balances[currency] = balance
+}
+
+// observe reports the verify success ratio, once a pin has been verified, and the last balance of every currency
+func (inst *Instrumentation) observe(ctx context.Context, o metric.Observer) error {
+	inst.mu.Lock()
+	defer inst.mu.Unlock()
+	if inst.verifies > 0 {
+		o.ObserveFloat64(inst.ratio, float64(inst.verified)/float64(inst.verifies))
+	}
+	for currency, balance := range inst.balances {
+		o.ObserveFloat64(inst.balance, balance, metric.WithAttributes(KeyCurrency.String(currency)))
+	}
+	return nil
+}
+
+func retries(attempts int) int {
+	if attempts < 1 {
+		return 0
+	}
+	return attempts - 1
+}
+
+// errorType is the http status of a failed response, or _OTHER when termii did not respond
+func errorType(result termii.CallResult) string {
+	if result.StatusCode >= 400 {
+		return strconv.Itoa(result.StatusCode)
+	}
+	return "_OTHER"
+}
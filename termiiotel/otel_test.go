@@ -0,0 +1,174 @@
+package termiiotel_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	termii "github.com/Uchencho/go-termii"
+	"github.com/Uchencho/go-termii/termiiotel"
+	"github.com/Uchencho/go-termii/termiitest"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func collect(t *testing.T, reader sdkmetric.Reader) map[string]metricdata.Aggregation {
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+	return metrics
+}
+
+func attr(attrs []attribute.KeyValue, key attribute.Key) attribute.Value {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestInstrumentation(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	inst, err := termiiotel.New(
+		termiiotel.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		termiiotel.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	assert.NoError(t, err)
+
+	srv := termiitest.NewServer(termiitest.WithBalance(100))
+	defer srv.Close()
+
+	var traced []bool
+	c := srv.Client(
+		termii.WithInstrumentation(inst),
+		termii.WithRetryPolicy(termii.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}),
+		termii.WithMiddleware(func(next termii.Doer) termii.Doer {
+			return termii.DoerFunc(func(req *http.Request) (*http.Response, error) {
+				traced = append(traced, trace.SpanContextFromContext(req.Context()).IsValid())
+				return next.Do(req)
+			})
+		}),
+	)
+
+	_, err = c.SendBulkMessage(termii.BulkMessageRequest{To: []string{"2347880234567", "2348109077743"}, From: "Acme", Sms: "Hi", Type: termii.SMSTypePlain, Channel: termii.ChannelGeneric})
+	assert.NoError(t, err)
+
+	token, err := c.SendToken(termii.SendTokenRequest{
+		To:             "2348109077743",
+		From:           "Acme",
+		Channel:        termii.ChannelDND,
+		MessageType:    termii.MessageTypeNumeric,
+		PinType:        termii.PinTypeNumeric,
+		PinAttempts:    3,
+		PinTimeToLive:  5,
+		PinLength:      6,
+		PinPlaceholder: "< 1234 >",
+		MessageText:    "Your pin is < 1234 >",
+	})
+	assert.NoError(t, err)
+	pin, _ := srv.LastPin("2348109077743")
+	c.VerifyToken(termii.VerifyTokenRequest{PinID: token.PinID, Pin: "000000"})
+	c.VerifyToken(termii.VerifyTokenRequest{PinID: token.PinID, Pin: pin.Pin})
+
+	balance, err := c.GetBalance()
+	assert.NoError(t, err)
+
+	srv.InjectError(termii.EndpointGetBalance, http.StatusServiceUnavailable, "Service unavailable", 2)
+	_, err = c.GetBalance()
+	assert.Error(t, err)
+
+	t.Run("A span is recorded per request", func(t *testing.T) {
+		ended := spans.Ended()
+		assert.Len(t, ended, 6)
+		assert.Equal(t, []bool{true, true, true, true, true, true, true}, traced)
+
+		first := ended[0]
+		assert.Equal(t, "POST api/sms/send/bulk", first.Name())
+		assert.Equal(t, trace.SpanKindClient, first.SpanKind())
+		assert.Equal(t, "api/sms/send/bulk", attr(first.Attributes(), termiiotel.KeyEndpoint).AsString())
+		assert.Equal(t, int64(http.StatusOK), attr(first.Attributes(), "http.response.status_code").AsInt64())
+		assert.Equal(t, int64(0), attr(first.Attributes(), termiiotel.KeyRetryCount).AsInt64())
+		assert.Equal(t, codes.Unset, first.Status().Code)
+	})
+
+	t.Run("Failed spans carry the status, termii code and retries", func(t *testing.T) {
+		ended := spans.Ended()
+		last := ended[len(ended)-1]
+		assert.Equal(t, "GET api/get-balance", last.Name())
+		assert.Equal(t, int64(http.StatusServiceUnavailable), attr(last.Attributes(), "http.response.status_code").AsInt64())
+		assert.Equal(t, "503", attr(last.Attributes(), termiiotel.KeyCode).AsString())
+		assert.Equal(t, int64(1), attr(last.Attributes(), termiiotel.KeyRetryCount).AsInt64())
+		assert.Equal(t, codes.Error, last.Status().Code)
+		assert.NotContains(t, last.Status().Description, srv.APIKey())
+	})
+
+	metrics := collect(t, reader)
+
+	t.Run("Requests are counted by endpoint and outcome", func(t *testing.T) {
+		requests := metrics[termiiotel.MetricRequests].(metricdata.Sum[int64])
+		counts := make(map[string]int64)
+		for _, dp := range requests.DataPoints {
+			ep, _ := dp.Attributes.Value(termiiotel.KeyEndpoint)
+			outcome, _ := dp.Attributes.Value(termiiotel.KeyOutcome)
+			counts[ep.AsString()+" "+outcome.AsString()] += dp.Value
+		}
+		assert.Equal(t, map[string]int64{
+			"api/sms/send/bulk success":  1,
+			"api/sms/otp/send success":   1,
+			"api/sms/otp/verify success": 2,
+			"api/get-balance success":    1,
+			"api/get-balance error":      1,
+		}, counts)
+
+		duration := metrics[termiiotel.MetricRequestDuration].(metricdata.Histogram[float64])
+		var recorded uint64
+		for _, dp := range duration.DataPoints {
+			recorded += dp.Count
+		}
+		assert.Equal(t, uint64(6), recorded)
+	})
+
+	t.Run("Messages are counted by channel", func(t *testing.T) {
+		sent := metrics[termiiotel.MetricMessagesSent].(metricdata.Sum[int64])
+		counts := make(map[string]int64)
+		for _, dp := range sent.DataPoints {
+			channel, _ := dp.Attributes.Value(termiiotel.KeyChannel)
+			counts[channel.AsString()] += dp.Value
+		}
+		assert.Equal(t, map[string]int64{"generic": 2, "dnd": 1}, counts)
+	})
+
+	t.Run("The verify success ratio is reported", func(t *testing.T) {
+		ratio := metrics[termiiotel.MetricVerifySuccessRate].(metricdata.Gauge[float64])
+		assert.Len(t, ratio.DataPoints, 1)
+		assert.Equal(t, 0.5, ratio.DataPoints[0].Value)
+
+		verifications := metrics[termiiotel.MetricVerifications].(metricdata.Sum[int64])
+		assert.Len(t, verifications.DataPoints, 2)
+	})
+
+	t.Run("The last balance is reported", func(t *testing.T) {
+		gauge := metrics[termiiotel.MetricWalletBalance].(metricdata.Gauge[float64])
+		assert.Len(t, gauge.DataPoints, 1)
+		assert.Equal(t, float64(balance.Balance), gauge.DataPoints[0].Value)
+		currency, _ := gauge.DataPoints[0].Attributes.Value(termiiotel.KeyCurrency)
+		assert.Equal(t, balance.Currency, currency.AsString())
+	})
+}
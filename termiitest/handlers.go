@@ -0,0 +1,765 @@
+package termiitest
+
+import (
+	"crypto/rand"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	termii "github.com/Uchencho/go-termii"
+	"github.com/Uchencho/go-termii/phone"
+)
+
+// timeLayout is the layout of the timestamps in responses
+const timeLayout = "2006-01-02 15:04:05"
+
+// Statuses of the pins and numbers the server knows of
+const (
+	// MessageStatusSent is the status of a recorded message until it is changed with SetMessageStatus
+	MessageStatusSent = "Message Sent"
+	dndActive         = "DND blacklisted"
+	dndInactive       = "DND not active on phone number"
+	testNetwork       = "Termii Test Network"
+)
+
+// Pin is a representation of a pin issued by the server
+type Pin struct {
+	ID           string
+	To           string
+	Pin          string
+	AttemptsLeft int
+	ExpiresAt    time.Time
+	Verified     bool
+}
+
+// Pins returns every pin issued by the server, in the order they were issued
+func (s *Server) Pins() []Pin {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pins := make([]Pin, len(s.pins))
+	for i, p := range s.pins {
+		pins[i] = *p
+	}
+	return pins
+}
+
+// LastPin returns the last pin issued to to, a phone number or email address
+func (s *Server) LastPin(to string) (Pin, bool) {
+	pins := s.Pins()
+	for i := len(pins) - 1; i >= 0; i-- {
+		if pins[i].To == to {
+			return pins[i], true
+		}
+	}
+	return Pin{}, false
+}
+
+// Messages returns every message recorded by the server, in the order they were sent
+func (s *Server) Messages() []termii.HistoryResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]termii.HistoryResponse(nil), s.messages...)
+}
+
+// SetMessageStatus changes the status of every recorded message with messageID, it reports whether there was any
+func (s *Server) SetMessageStatus(messageID, status string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	found := false
+	for i := range s.messages {
+		if s.messages[i].MessageID == messageID {
+			s.messages[i].Status = status
+			found = true
+		}
+	}
+	return found
+}
+
+// SetDND sets whether do not disturb is active on number, as reported by VerifyNumber
+func (s *Server) SetDND(number string, active bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dnd[number] = active
+}
+
+// SetSenderIDStatus changes the status of a registered sender id, it reports whether the sender id exists
+func (s *Server) SetSenderIDStatus(senderID, status string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range s.senderIDs {
+		if s.senderIDs[i].SenderID == senderID {
+			s.senderIDs[i].Status = status
+			return true
+		}
+	}
+	return false
+}
+
+// charge debits the wallet, it reports false and leaves the wallet untouched when the balance is too low
+func (s *Server) charge(amount int) bool {
+	if amount > s.balance {
+		return false
+	}
+	s.balance -= amount
+	return true
+}
+
+// pages returns the number of pages sms is billed as over channel
+func pages(channel termii.Channel, sms string) int {
+	if channel == termii.ChannelWhatsApp || channel == termii.ChannelEmail {
+		return 1
+	}
+	return termii.AnalyzeSMS(sms).Pages
+}
+
+var insufficientBalance = fail(http.StatusBadRequest, "Insufficient balance")
+
+func invalid(err error) response {
+	return fail(http.StatusBadRequest, err.Error())
+}
+
+// record stores a message for GetHistory
+func (s *Server) record(messageID, sender, receiver, message string, amount int, smsType, sendBy string) {
+	s.messages = append(s.messages, termii.HistoryResponse{
+		Sender:    sender,
+		Receiver:  receiver,
+		Message:   message,
+		Amount:    amount,
+		Status:    MessageStatusSent,
+		SmsType:   smsType,
+		SendBy:    sendBy,
+		MessageID: messageID,
+		CreatedAt: s.now().Format(timeLayout),
+	})
+}
+
+func (s *Server) messageID() string {
+	return fmt.Sprintf("3017%021d", s.nextID())
+}
+
+// pinID returns a random uuid formatted id
+func pinID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// pin returns the pin issued with id, s.mu must be held
+func (s *Server) pin(id string) (*Pin, bool) {
+	for _, p := range s.pins {
+		if p.ID == id {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// issuePin creates a pin of length characters of pinType for to
+func (s *Server) issuePin(to string, pinType termii.PinType, length, attempts, ttlMinutes int) *Pin {
+	alphabet := "0123456789"
+	if pinType == termii.PinTypeAlphanumeric {
+		alphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	}
+	code := make([]byte, length)
+	for i := range code {
+		n, _ := rand.Int(rand.Reader, big.NewInt(int64(len(alphabet))))
+		code[i] = alphabet[n.Int64()]
+	}
+
+	p := &Pin{
+		ID:           pinID(),
+		To:           to,
+		Pin:          string(code),
+		AttemptsLeft: attempts,
+		ExpiresAt:    s.now().Add(time.Duration(ttlMinutes) * time.Minute),
+	}
+	s.pins = append(s.pins, p)
+	return p
+}
+
+func (s *Server) fetchSenderIDs(r request) response {
+	page := r.page()
+	start, end, last := pageBounds(len(s.senderIDs), page)
+
+	resp := termii.FetchSenderIdResponse{
+		CurrentPage:  page,
+		Data:         append([]termii.FetchSenderIdData{}, s.senderIDs[start:end]...),
+		FirstPageURL: s.pageURL(termii.EndpointFetchSenderID, 1),
+		LastPage:     last,
+		LastPageURL:  s.pageURL(termii.EndpointFetchSenderID, last),
+		Path:         s.URL + "/" + string(termii.EndpointFetchSenderID),
+		PerPage:      pageSize,
+		Total:        len(s.senderIDs),
+	}
+	if end > start {
+		resp.From, resp.To = start+1, end
+	}
+	if page < last {
+		resp.NextPageURL = s.pageURL(termii.EndpointFetchSenderID, page+1)
+	}
+	if page > 1 {
+		resp.PrevPageURL = s.pageURL(termii.EndpointFetchSenderID, page-1)
+	}
+	return ok(resp)
+}
+
+func (s *Server) registerSender(r request) response {
+	var req termii.RegisterSenderIdRequest
+	if err := r.decode(&req); err != nil {
+		return invalid(err)
+	}
+	for _, existing := range s.senderIDs {
+		if strings.EqualFold(existing.SenderID, req.SenderID) {
+			return fail(http.StatusBadRequest, "Sender id already exists")
+		}
+	}
+	s.senderIDs = append(s.senderIDs, termii.FetchSenderIdData{
+		SenderID:  req.SenderID,
+		Status:    "pending",
+		Company:   req.Company,
+		Usecase:   req.Usecase,
+		CreatedAt: s.now().Format(timeLayout),
+	})
+	return ok(termii.RegisterSenderResponse{Code: "ok", Message: "Sender Id requested. You will be contacted by your account manager."})
+}
+
+func (s *Server) sendMessage(r request) response {
+	var req termii.SendMessageRequest
+	if err := r.decode(&req); err != nil {
+		return invalid(err)
+	}
+	amount := pages(req.Channel, req.Sms) * s.pageCost
+	if !s.charge(amount) {
+		return insufficientBalance
+	}
+	id := s.messageID()
+	s.record(id, req.From, req.To, req.Sms, amount, string(req.Type), string(req.Channel))
+	return ok(termii.SendMessageResponse{MessageID: id, Message: "Successfully Sent", Balance: s.balance, User: s.user})
+}
+
+func (s *Server) sendBulkMessage(r request) response {
+	var req termii.BulkMessageRequest
+	if err := r.decode(&req); err != nil {
+		return invalid(err)
+	}
+	perRecipient := pages(req.Channel, req.Sms) * s.pageCost
+	if !s.charge(perRecipient * len(req.To)) {
+		return insufficientBalance
+	}
+	id := s.messageID()
+	for _, to := range req.To {
+		s.record(id, req.From, to, req.Sms, perRecipient, string(req.Type), string(req.Channel))
+	}
+	return ok(termii.BulkMessageResponse{Code: "ok", MessageID: id, Message: "Successfully Sent", Balance: s.balance, User: s.user})
+}
+
+func (s *Server) sendAutoGeneratedMessage(r request) response {
+	var req termii.AutoGeneratedMessageRequest
+	if err := r.decode(&req); err != nil {
+		return invalid(err)
+	}
+	amount := pages(termii.ChannelGeneric, req.Sms) * s.pageCost
+	if !s.charge(amount) {
+		return insufficientBalance
+	}
+	id := s.messageID()
+	s.record(id, "Termii", req.To, req.Sms, amount, string(termii.SMSTypePlain), "number")
+	return ok(termii.AutoGeneratedMessageResponse{Code: "ok", MessageID: id, Message: "Successfully Sent", Balance: s.balance, User: s.user})
+}
+
+func (s *Server) setDeviceTemplate(r request) response {
+	var req termii.TemplateRequest
+	if err := r.decode(&req); err != nil {
+		return invalid(err)
+	}
+	if !s.charge(s.pageCost) {
+		return insufficientBalance
+	}
+	id := s.messageID()
+	sms := fmt.Sprintf("Your %s code is %d, it expires in %s", req.Data.ProductName, req.Data.Otp, req.Data.ExpiryTime)
+	s.record(id, req.DeviceID, req.PhoneNumber, sms, s.pageCost, string(termii.SMSTypePlain), "device")
+	return ok([]termii.TemplateResponse{{
+		Code: "ok", MessageID: id, Message: "Successfully Sent", Balance: strconv.Itoa(s.balance), User: s.user,
+	}})
+}
+
+func (s *Server) sendToken(r request) response {
+	var req termii.SendTokenRequest
+	if err := r.decode(&req); err != nil {
+		return invalid(err)
+	}
+	to := req.To
+	if req.Channel == termii.ChannelEmail {
+		to = req.EmailAddress
+	}
+	if !s.charge(s.pageCost) {
+		return insufficientBalance
+	}
+
+	p := s.issuePin(to, req.PinType, req.PinLength, req.PinAttempts, req.PinTimeToLive)
+	sms := strings.Replace(req.MessageText, req.PinPlaceholder, p.Pin, -1)
+	s.record(s.messageID(), req.From, to, sms, s.pageCost, string(req.MessageType), string(req.Channel))
+	return ok(termii.SendTokenResponse{PinID: p.ID, To: to, SmsStatus: "Message Sent"})
+}
+
+func (s *Server) verifyToken(r request) response {
+	var req termii.VerifyTokenRequest
+	if err := r.decode(&req); err != nil {
+		return invalid(err)
+	}
+	p, found := s.pin(req.PinID)
+	switch {
+	case !found:
+		return fail(http.StatusNotFound, "Pin not found")
+	case p.Verified:
+		return fail(http.StatusBadRequest, "Pin already verified")
+	case !s.now().Before(p.ExpiresAt):
+		return fail(http.StatusBadRequest, "Pin expired")
+	case p.AttemptsLeft <= 0:
+		return fail(http.StatusBadRequest, "Maximum attempts exceeded")
+	}
+
+	p.AttemptsLeft--
+	if !strings.EqualFold(p.Pin, req.Pin) {
+		return ok(termii.VerifyTokenResponse{PinID: p.ID, Verified: "False", Msisdn: p.To})
+	}
+	p.Verified = true
+	return ok(termii.VerifyTokenResponse{PinID: p.ID, Verified: "True", Msisdn: p.To})
+}
+
+func (s *Server) generateToken(r request) response {
+	var req termii.GenerateTokenRequest
+	if err := r.decode(&req); err != nil {
+		return invalid(err)
+	}
+	p := s.issuePin(req.PhoneNumber, req.PinType, req.PinLength, req.PinAttempts, req.PinTimeToLive)
+	return ok(termii.GenerateTokenResponse{
+		Status: "success",
+		Data:   termii.InAppTokenDataResponse{PinID: p.ID, Otp: p.Pin, PhoneNumber: req.PhoneNumber},
+	})
+}
+
+func (s *Server) sendVoiceToken(r request) response {
+	var req termii.VoiceTokenRequest
+	if err := r.decode(&req); err != nil {
+		return invalid(err)
+	}
+	if !s.charge(s.pageCost) {
+		return insufficientBalance
+	}
+	p := s.issuePin(req.PhoneNumber, termii.PinTypeNumeric, req.PinLength, req.PinAttempts, req.PinTimeToLive)
+	id := s.messageID()
+	s.record(id, "Termii", req.PhoneNumber, p.Pin, s.pageCost, "voice", "voice")
+	return ok(termii.VoiceTokenResponse{
+		Code: "ok", PinID: p.ID, MessageID: id, Message: "Successfully Sent", Balance: s.balance, User: s.user,
+	})
+}
+
+func (s *Server) sendVoiceCall(r request) response {
+	var req termii.VoiceCallRequest
+	if err := r.decode(&req); err != nil {
+		return invalid(err)
+	}
+	if !s.charge(s.pageCost) {
+		return insufficientBalance
+	}
+	id := s.messageID()
+	s.record(id, "Termii", req.PhoneNumber, strconv.Itoa(req.Code), s.pageCost, "voice", "voice")
+	return ok(termii.VoiceCallResponse{Code: "ok", MessageID: id, Message: "Successfully Sent", Balance: s.balance, User: s.user})
+}
+
+func (s *Server) sendEmailToken(r request) response {
+	var req termii.EmailTokenRequest
+	if err := r.decode(&req); err != nil {
+		return invalid(err)
+	}
+	if !s.charge(s.pageCost) {
+		return insufficientBalance
+	}
+	id := s.messageID()
+	s.record(id, "Termii", req.EmailAddress, req.Code, s.pageCost, "email", string(termii.ChannelEmail))
+	return ok(termii.EmailTokenResponse{Code: "ok", MessageID: id, Message: "Successfully Sent", Balance: s.balance, User: s.user})
+}
+
+func (s *Server) getBalance(r request) response {
+	return ok(termii.GetBalanceResponse{User: s.user, Balance: s.balance, Currency: s.currency})
+}
+
+func (s *Server) verifyNumber(r request) response {
+	var req termii.VerifyNumberRequest
+	if err := r.decode(&req); err != nil {
+		return invalid(err)
+	}
+	status := dndInactive
+	if s.dnd[req.PhoneNumber] {
+		status = dndActive
+	}
+	return ok(termii.VerifyNumberResponse{Number: req.PhoneNumber, Status: status, Network: testNetwork, NetworkCode: "00000"})
+}
+
+func (s *Server) getStatus(r request) response {
+	var req termii.StatusRequest
+	if err := r.decode(&req); err != nil {
+		return invalid(err)
+	}
+	country, found := phone.LookupCountry(req.CountryCode)
+	if !found {
+		country, found = phone.DetectCountry(req.PhoneNumber)
+	}
+	if !found {
+		return fail(http.StatusBadRequest, "Unsupported country")
+	}
+	return ok(termii.StatusResponse{Result: []termii.StatusResult{{
+		RouteDetail:    termii.RouteDetail{Number: req.PhoneNumber},
+		CountryDetail:  termii.CountryDetail{CountryCode: country.DialCode, Iso: country.ISO},
+		OperatorDetail: termii.OperatorDetail{OperatorName: testNetwork, LineType: "Mobile"},
+		Status:         http.StatusOK,
+	}}})
+}
+
+func (s *Server) getHistory(r request) response {
+	messageID := r.URL.Query().Get("message_id")
+	history := []termii.HistoryResponse{}
+	for _, m := range s.messages {
+		if messageID == "" || m.MessageID == messageID {
+			history = append(history, m)
+		}
+	}
+	return ok(history)
+}
+
+// phonebook is a representation of a phonebook and its contacts
+type phonebook struct {
+	pid         int
+	info        termii.Phonebook
+	description string
+	contacts    []termii.Contact
+}
+
+func (s *Server) phonebook(id string) (*phonebook, int) {
+	for i, pb := range s.phonebooks {
+		if pb.info.ID == id {
+			return pb, i
+		}
+	}
+	return nil, -1
+}
+
+func (s *Server) listPhonebooks(r request) response {
+	page := r.page()
+	start, end, last := pageBounds(len(s.phonebooks), page)
+
+	resp := termii.ListPhonebooksResponse{Data: []termii.Phonebook{}}
+	for _, pb := range s.phonebooks[start:end] {
+		info := pb.info
+		info.TotalNumberOfContacts = len(pb.contacts)
+		resp.Data = append(resp.Data, info)
+	}
+	resp.Links, resp.Meta = s.pagination(termii.EndpointPhonebooks, len(s.phonebooks), page, start, end, last)
+	return ok(resp)
+}
+
+func (s *Server) createPhonebook(r request) response {
+	var req termii.PhonebookRequest
+	if err := r.decode(&req); err != nil {
+		return invalid(err)
+	}
+	for _, pb := range s.phonebooks {
+		if strings.EqualFold(pb.info.Name, req.PhonebookName) {
+			return fail(http.StatusBadRequest, "Phonebook already exists")
+		}
+	}
+	now := s.now().Format(timeLayout)
+	pid := s.nextID()
+	s.phonebooks = append(s.phonebooks, &phonebook{
+		pid:         pid,
+		info:        termii.Phonebook{ID: fmt.Sprintf("pb%022d", pid), Name: req.PhonebookName, DateCreated: now, LastUpdated: now},
+		description: req.Description,
+	})
+	return ok(termii.PhonebookResponse{Message: "Phonebook added successfully"})
+}
+
+func (s *Server) updatePhonebook(r request) response {
+	pb, _ := s.phonebook(r.params["phonebook_id"])
+	if pb == nil {
+		return fail(http.StatusNotFound, "Phonebook not found")
+	}
+	var req termii.PhonebookRequest
+	if err := r.decode(&req); err != nil {
+		return invalid(err)
+	}
+	pb.info.Name, pb.description = req.PhonebookName, req.Description
+	pb.info.LastUpdated = s.now().Format(timeLayout)
+	return ok(termii.PhonebookResponse{Message: "Phonebook Updated Successfully"})
+}
+
+func (s *Server) deletePhonebook(r request) response {
+	pb, i := s.phonebook(r.params["phonebook_id"])
+	if pb == nil {
+		return fail(http.StatusNotFound, "Phonebook not found")
+	}
+	s.phonebooks = append(s.phonebooks[:i], s.phonebooks[i+1:]...)
+	return ok(termii.PhonebookResponse{Message: "Phonebook deleted successfully"})
+}
+
+func (s *Server) listContacts(r request) response {
+	pb, _ := s.phonebook(r.params["phonebook_id"])
+	if pb == nil {
+		return fail(http.StatusNotFound, "Phonebook not found")
+	}
+	page := r.page()
+	start, end, last := pageBounds(len(pb.contacts), page)
+
+	resp := termii.ListContactsResponse{Data: append([]termii.Contact{}, pb.contacts[start:end]...)}
+	ep := termii.Endpoint(strings.Replace(string(termii.EndpointContacts), "{phonebook_id}", pb.info.ID, 1))
+	resp.Links, resp.Meta = s.pagination(ep, len(pb.contacts), page, start, end, last)
+	return ok(resp)
+}
+
+func (s *Server) addContact(r request) response {
+	pb, _ := s.phonebook(r.params["phonebook_id"])
+	if pb == nil {
+		return fail(http.StatusNotFound, "Phonebook not found")
+	}
+	var req termii.AddContactRequest
+	if err := r.decode(&req); err != nil {
+		return invalid(err)
+	}
+	contact := s.addToPhonebook(pb, termii.Contact{
+		PhoneNumber:  contactNumber(req.PhoneNumber, req.CountryCode),
+		EmailAddress: req.EmailAddress,
+		FirstName:    req.FirstName,
+		LastName:     req.LastName,
+		Company:      req.Company,
+	})
+	return ok(termii.AddContactResponse{Data: contact})
+}
+
+func (s *Server) addToPhonebook(pb *phonebook, contact termii.Contact) termii.Contact {
+	now := s.now().Format(timeLayout)
+	contact.ID, contact.PID = s.nextID(), pb.pid
+	contact.CreateAt, contact.UpdatedAt = now, now
+	pb.contacts = append(pb.contacts, contact)
+	pb.info.LastUpdated = now
+	return contact
+}
+
+// contactNumber prefixes number with countryCode when it is in national format
+func contactNumber(number, countryCode string) string {
+	if normalized, err := phone.Normalize(number, countryCode); err == nil {
+		return normalized
+	}
+	return number
+}
+
+func (s *Server) deleteContact(r request) response {
+	pb, _ := s.phonebook(r.params["phonebook_id"])
+	if pb == nil {
+		return fail(http.StatusNotFound, "Phonebook not found")
+	}
+	for i, contact := range pb.contacts {
+		if strconv.Itoa(contact.ID) == r.params["contact_id"] {
+			pb.contacts = append(pb.contacts[:i], pb.contacts[i+1:]...)
+			return ok(termii.DeleteContactResponse{Message: "Contact deleted successfully"})
+		}
+	}
+	return fail(http.StatusNotFound, "Contact not found")
+}
+
+func (s *Server) uploadContacts(r request) response {
+	pb, _ := s.phonebook(r.FormValue("pid"))
+	if pb == nil {
+		return fail(http.StatusNotFound, "Phonebook not found")
+	}
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		return fail(http.StatusBadRequest, "A contacts file is required")
+	}
+	defer file.Close()
+
+	contacts, err := readContacts(file, r.FormValue("country_code"))
+	if err != nil {
+		return invalid(err)
+	}
+	for _, contact := range contacts {
+		s.addToPhonebook(pb, contact)
+	}
+	return ok(termii.UploadContactsResponse{Message: "Your list is being uploaded in the background."})
+}
+
+// readContacts parses a csv of contacts. A header naming the phone_number column is optional,
+// without one the first column is the phone number.
+func readContacts(r io.Reader, countryCode string) ([]termii.Contact, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	columns := map[string]int{"phone_number": 0}
+	header := make(map[string]int)
+	for i, name := range rows[0] {
+		header[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := header["phone_number"]; ok {
+		columns, rows = header, rows[1:]
+	}
+	field := func(row []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+
+	contacts := make([]termii.Contact, 0, len(rows))
+	for _, row := range rows {
+		contacts = append(contacts, termii.Contact{
+			PhoneNumber:  contactNumber(field(row, "phone_number"), countryCode),
+			EmailAddress: field(row, "email_address"),
+			FirstName:    field(row, "first_name"),
+			LastName:     field(row, "last_name"),
+			Company:      field(row, "company"),
+		})
+	}
+	return contacts, nil
+}
+
+// campaign is a representation of a campaign and the messages it sent
+type campaign struct {
+	info     termii.Campaign
+	messages []termii.CampaignMessage
+}
+
+func (s *Server) sendCampaign(r request) response {
+	var req termii.SendCampaignRequest
+	if err := r.decode(&req); err != nil {
+		return invalid(err)
+	}
+	pb, _ := s.phonebook(req.PhonebookID)
+	if pb == nil {
+		return fail(http.StatusNotFound, "Phonebook not found")
+	}
+
+	now := s.now().Format(timeLayout)
+	c := &campaign{info: termii.Campaign{
+		CampaignID:      fmt.Sprintf("C%09d", s.nextID()),
+		PhoneBook:       pb.info.Name,
+		Sender:          req.SenderID,
+		CampType:        req.CampaignType,
+		Channel:         string(req.Channel),
+		TotalRecipients: len(pb.contacts),
+		RunAt:           now,
+		Status:          "Sent",
+		CreatedAt:       now,
+	}}
+
+	if req.ScheduleTime != "" {
+		c.info.RunAt, c.info.Status = req.ScheduleTime, "Scheduled"
+	} else {
+		perRecipient := pages(req.Channel, req.Message) * s.pageCost
+		if !s.charge(perRecipient * len(pb.contacts)) {
+			return insufficientBalance
+		}
+		for _, contact := range pb.contacts {
+			id := s.messageID()
+			s.record(id, req.SenderID, contact.PhoneNumber, req.Message, perRecipient, req.MessageType, string(req.Channel))
+			c.messages = append(c.messages, termii.CampaignMessage{
+				ID:          s.nextID(),
+				Sender:      req.SenderID,
+				Receiver:    contact.PhoneNumber,
+				Message:     req.Message,
+				Amount:      perRecipient,
+				Channel:     string(req.Channel),
+				SmsType:     req.MessageType,
+				MessageID:   id,
+				Status:      MessageStatusSent,
+				DateCreated: now,
+				LastUpdated: now,
+			})
+		}
+	}
+	s.campaigns = append(s.campaigns, c)
+	return ok(termii.SendCampaignResponse{
+		Message: "Your campaign has been successfully sent.", CampaignID: c.info.CampaignID, Status: "success",
+	})
+}
+
+func (s *Server) listCampaigns(r request) response {
+	page := r.page()
+	start, end, last := pageBounds(len(s.campaigns), page)
+
+	resp := termii.ListCampaignsResponse{Data: []termii.Campaign{}}
+	for _, c := range s.campaigns[start:end] {
+		resp.Data = append(resp.Data, c.info)
+	}
+	resp.Links, resp.Meta = s.pagination(termii.EndpointCampaigns, len(s.campaigns), page, start, end, last)
+	return ok(resp)
+}
+
+func (s *Server) campaignHistory(r request) response {
+	for _, c := range s.campaigns {
+		if c.info.CampaignID != r.params["campaign_id"] {
+			continue
+		}
+		page := r.page()
+		start, end, last := pageBounds(len(c.messages), page)
+
+		resp := termii.CampaignHistoryResponse{Data: append([]termii.CampaignMessage{}, c.messages[start:end]...)}
+		ep := termii.Endpoint(strings.Replace(string(termii.EndpointCampaign), "{campaign_id}", c.info.CampaignID, 1))
+		resp.Links, resp.Meta = s.pagination(ep, len(c.messages), page, start, end, last)
+		return ok(resp)
+	}
+	return fail(http.StatusNotFound, "Campaign not found")
+}
+
+// pageBounds returns the bounds of page in a list of total items and the number of the last page
+func pageBounds(total, page int) (start, end, last int) {
+	last = (total + pageSize - 1) / pageSize
+	if last == 0 {
+		last = 1
+	}
+	start = (page - 1) * pageSize
+	if start > total {
+		start = total
+	}
+	end = start + pageSize
+	if end > total {
+		end = total
+	}
+	return start, end, last
+}
+
+func (s *Server) pageURL(ep termii.Endpoint, page int) string {
+	return fmt.Sprintf("%s/%s?page=%d", s.URL, ep, page)
+}
+
+func (s *Server) pagination(ep termii.Endpoint, total, page, start, end, last int) (termii.PaginationLinks, termii.PaginationMeta) {
+	links := termii.PaginationLinks{First: s.pageURL(ep, 1), Last: s.pageURL(ep, last)}
+	if page > 1 {
+		links.Prev = s.pageURL(ep, page-1)
+	}
+	if page < last {
+		links.Next = s.pageURL(ep, page+1)
+	}
+	meta := termii.PaginationMeta{
+		CurrentPage: page,
+		LastPage:    last,
+		Path:        s.URL + "/" + string(ep),
+		PerPage:     pageSize,
+		Total:       total,
+	}
+	if end > start {
+		meta.From, meta.To = start+1, end
+	}
+	return links, meta
+}
@@ -0,0 +1,405 @@
+// Package termiitest provides an in process fake of the termii api for integration tests.
+//
+// The fake keeps the state termii would: it charges the wallet for every message, issues pins that
+// VerifyToken checks against their attempts and time to live, records messages for GetHistory and
+// stores sender ids, phonebooks, contacts and campaigns. Latency, errors and rate limits can be injected
+// to exercise the failure handling of the code under test.
+package termiitest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	termii "github.com/Uchencho/go-termii"
+)
+
+// Defaults of a Server
+const (
+	DefaultAPIKey   = "termiitest-api-key"
+	DefaultUser     = "Termii Test"
+	DefaultBalance  = 1000
+	DefaultCurrency = "NGN"
+	// DefaultPageCost is the amount charged for each page of a message, and for each token
+	DefaultPageCost = 4
+	// pageSize is the number of items in a page of paginated responses
+	pageSize = 15
+)
+
+// Option is a representation of a functional option used to configure a Server
+type Option func(*Server)
+
+// WithAPIKey sets the only api key the server accepts
+func WithAPIKey(apiKey string) Option {
+	return func(s *Server) {
+		s.apiKey = apiKey
+	}
+}
+
+// WithBalance sets the initial balance of the wallet
+func WithBalance(balance int) Option {
+	return func(s *Server) {
+		s.balance = balance
+	}
+}
+
+// WithCurrency sets the currency of the wallet
+func WithCurrency(currency string) Option {
+	return func(s *Server) {
+		s.currency = currency
+	}
+}
+
+// WithUser sets the name of the account holder returned in responses
+func WithUser(user string) Option {
+	return func(s *Server) {
+		s.user = user
+	}
+}
+
+// WithPageCost sets the amount charged for each page of a message, and for each token
+func WithPageCost(cost int) Option {
+	return func(s *Server) {
+		s.pageCost = cost
+	}
+}
+
+// Server is a stateful fake of the termii api. It is safe for concurrent use.
+type Server struct {
+	*httptest.Server
+
+	apiKey   string
+	user     string
+	currency string
+	pageCost int
+
+	mu       sync.Mutex
+	balance  int
+	offset   time.Duration
+	sequence int
+
+	pins       []*Pin
+	messages   []termii.HistoryResponse
+	senderIDs  []termii.FetchSenderIdData
+	dnd        map[string]bool
+	phonebooks []*phonebook
+	campaigns  []*campaign
+
+	latency  time.Duration
+	failures map[termii.Endpoint][]failure
+	limit    rateLimit
+}
+
+// NewServer starts a fake termii server, it should be closed once the test is done
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		apiKey:   DefaultAPIKey,
+		user:     DefaultUser,
+		currency: DefaultCurrency,
+		pageCost: DefaultPageCost,
+		balance:  DefaultBalance,
+		dnd:      make(map[string]bool),
+		failures: make(map[termii.Endpoint][]failure),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.Server = httptest.NewServer(s)
+	return s
+}
+
+// Client returns a termii client configured to talk to the server with its api key
+func (s *Server) Client(opts ...termii.Option) termii.Client {
+	c, err := termii.New(append([]termii.Option{termii.WithAPIKey(s.apiKey), termii.WithBaseURL(s.URL)}, opts...)...)
+	if err != nil {
+		panic(fmt.Sprintf("termiitest - unable to create client: %v", err))
+	}
+	return c
+}
+
+// APIKey returns the api key the server accepts
+func (s *Server) APIKey() string {
+	return s.apiKey
+}
+
+// Balance returns the current balance of the wallet
+func (s *Server) Balance() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.balance
+}
+
+// SetBalance sets the balance of the wallet
+func (s *Server) SetBalance(balance int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.balance = balance
+}
+
+// Advance moves the clock of the server forward by d, expiring pins whose time to live has passed
+func (s *Server) Advance(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offset += d
+}
+
+// now returns the time on the clock of the server, s.mu must be held
+func (s *Server) now() time.Time {
+	return time.Now().Add(s.offset)
+}
+
+// nextID returns a numeric id unique to the server, s.mu must be held
+func (s *Server) nextID() int {
+	s.sequence++
+	return s.sequence
+}
+
+// failure is a representation of an injected error response
+type failure struct {
+	status  int
+	message string
+}
+
+// InjectError makes the next times requests to ep fail with status and message, e.g
+// InjectError(termii.EndpointSendToken, http.StatusInternalServerError, "Internal error", 2)
+func (s *Server) InjectError(ep termii.Endpoint, status int, message string, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < times; i++ {
+		s.failures[ep] = append(s.failures[ep], failure{status: status, message: message})
+	}
+}
+
+// InjectLatency delays every response by d, a request cancelled by the client is abandoned
+func (s *Server) InjectLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// rateLimit is a representation of a fixed window rate limit
+type rateLimit struct {
+	requests    int
+	window      time.Duration
+	windowStart time.Time
+	count       int
+}
+
+// SetRateLimit allows up to requests requests per window, further requests are refused with 429 and a
+// Retry-After header until the window ends. A non positive requests removes the limit.
+func (s *Server) SetRateLimit(requests int, window time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limit = rateLimit{requests: requests, window: window}
+}
+
+// allow applies the rate limit to a request, s.mu must be held
+func (s *Server) allow() (time.Duration, bool) {
+	l := &s.limit
+	if l.requests <= 0 {
+		return 0, true
+	}
+	now := time.Now()
+	if now.Sub(l.windowStart) >= l.window {
+		l.windowStart, l.count = now, 0
+	}
+	if l.count >= l.requests {
+		return l.window - now.Sub(l.windowStart), false
+	}
+	l.count++
+	return 0, true
+}
+
+// request is a representation of an incoming request matched to a route
+type request struct {
+	*http.Request
+	body   []byte
+	params map[string]string
+}
+
+// decode unmarshals the json body of the request into v
+func (r request) decode(v interface{}) error {
+	if len(r.body) == 0 {
+		return nil
+	}
+	return json.Unmarshal(r.body, v)
+}
+
+// page returns the page number requested, pages start at 1
+func (r request) page() int {
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		return 1
+	}
+	return page
+}
+
+// response is a representation of a response produced by a route
+type response struct {
+	status int
+	body   interface{}
+}
+
+func ok(body interface{}) response {
+	return response{status: http.StatusOK, body: body}
+}
+
+func fail(status int, message string) response {
+	return response{status: status, body: map[string]string{"code": strconv.Itoa(status), "message": message}}
+}
+
+// route is a representation of an operation of the api, handlers are called with s.mu held
+type route struct {
+	method   string
+	endpoint termii.Endpoint
+	handle   func(r request) response
+}
+
+func (s *Server) routes() []route {
+	return []route{
+		{http.MethodGet, termii.EndpointFetchSenderID, s.fetchSenderIDs},
+		{http.MethodPost, termii.EndpointRegisterSender, s.registerSender},
+		{http.MethodPost, termii.EndpointSendMessage, s.sendMessage},
+		{http.MethodPost, termii.EndpointSendBulkMessage, s.sendBulkMessage},
+		{http.MethodPost, termii.EndpointSendAutoGeneratedMessage, s.sendAutoGeneratedMessage},
+		{http.MethodPost, termii.EndpointSetDeviceTemplate, s.setDeviceTemplate},
+		{http.MethodPost, termii.EndpointSendToken, s.sendToken},
+		{http.MethodPost, termii.EndpointVerifyToken, s.verifyToken},
+		{http.MethodPost, termii.EndpointGetInAppToken, s.generateToken},
+		{http.MethodPost, termii.EndpointSendVoiceToken, s.sendVoiceToken},
+		{http.MethodPost, termii.EndpointSendVoiceCall, s.sendVoiceCall},
+		{http.MethodPost, termii.EndpointSendEmailToken, s.sendEmailToken},
+		{http.MethodGet, termii.EndpointGetBalance, s.getBalance},
+		{http.MethodGet, termii.EndpointVerifyNumber, s.verifyNumber},
+		{http.MethodGet, termii.EndpointGetStatus, s.getStatus},
+		{http.MethodGet, termii.EndpointGetHistory, s.getHistory},
+		{http.MethodPost, termii.EndpointUploadContacts, s.uploadContacts},
+		{http.MethodGet, termii.EndpointPhonebooks, s.listPhonebooks},
+		{http.MethodPost, termii.EndpointPhonebooks, s.createPhonebook},
+		{http.MethodPatch, termii.EndpointPhonebook, s.updatePhonebook},
+		{http.MethodDelete, termii.EndpointPhonebook, s.deletePhonebook},
+		{http.MethodGet, termii.EndpointContacts, s.listContacts},
+		{http.MethodPost, termii.EndpointContacts, s.addContact},
+		{http.MethodDelete, termii.EndpointContact, s.deleteContact},
+		{http.MethodPost, termii.EndpointSendCampaign, s.sendCampaign},
+		{http.MethodGet, termii.EndpointCampaigns, s.listCampaigns},
+		{http.MethodGet, termii.EndpointCampaign, s.campaignHistory},
+	}
+}
+
+// match returns the route serving method and path, along with the path parameters
+func (s *Server) match(method, path string) (route, map[string]string, bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for _, rt := range s.routes() {
+		if rt.method != method {
+			continue
+		}
+		if params, ok := matchPath(string(rt.endpoint), segments); ok {
+			return rt, params, true
+		}
+	}
+	return route{}, nil, false
+}
+
+func matchPath(template string, segments []string) (map[string]string, bool) {
+	parts := strings.Split(template, "/")
+	if len(parts) != len(segments) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, part := range parts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			params[strings.Trim(part, "{}")] = segments[i]
+			continue
+		}
+		if part != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeJSON(w, fail(http.StatusBadRequest, "unable to read request body"))
+		return
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	rt, params, found := s.match(r.Method, r.URL.EscapedPath())
+	if !found {
+		writeJSON(w, fail(http.StatusNotFound, "Not found"))
+		return
+	}
+
+	s.mu.Lock()
+	latency := s.latency
+	s.mu.Unlock()
+	if !sleep(r.Context(), latency) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if retryAfter, ok := s.allow(); !ok {
+		w.Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds()+1)))
+		writeJSON(w, fail(http.StatusTooManyRequests, "Too many requests"))
+		return
+	}
+	if apiKey(r, body) != s.apiKey {
+		writeJSON(w, fail(http.StatusUnauthorized, "Invalid api key"))
+		return
+	}
+	if queued := s.failures[rt.endpoint]; len(queued) > 0 {
+		s.failures[rt.endpoint] = queued[1:]
+		writeJSON(w, fail(queued[0].status, queued[0].message))
+		return
+	}
+
+	writeJSON(w, rt.handle(request{Request: r, body: body, params: params}))
+}
+
+// apiKey returns the api key of a request, sent in the query, the json body or a multipart form
+func apiKey(r *http.Request, body []byte) string {
+	if key := r.URL.Query().Get("api_key"); key != "" {
+		return key
+	}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		return r.FormValue("api_key")
+	}
+	var payload struct {
+		APIKey string `json:"api_key"`
+	}
+	json.Unmarshal(body, &payload)
+	return payload.APIKey
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func writeJSON(w http.ResponseWriter, res response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(res.status)
+	json.NewEncoder(w).Encode(res.body)
+}
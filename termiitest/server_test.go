@@ -0,0 +1,252 @@
+package termiitest_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	termii "github.com/Uchencho/go-termii"
+	"github.com/Uchencho/go-termii/termiitest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func tokenRequest(to string) termii.SendTokenRequest {
+	return termii.SendTokenRequest{
+		To:             to,
+		From:           "Acme",
+		Channel:        termii.ChannelDND,
+		MessageType:    termii.MessageTypeNumeric,
+		PinType:        termii.PinTypeNumeric,
+		PinAttempts:    2,
+		PinTimeToLive:  5,
+		PinLength:      6,
+		PinPlaceholder: "< 1234 >",
+		MessageText:    "Your pin is < 1234 >",
+	}
+}
+
+func TestMessaging(t *testing.T) {
+	srv := termiitest.NewServer(termiitest.WithBalance(10))
+	defer srv.Close()
+	c := srv.Client()
+
+	resp, err := c.SendMessage(termii.SendMessageRequest{
+		To: "2347880234567", From: "Acme", Sms: "Hello", Type: termii.SMSTypePlain, Channel: termii.ChannelGeneric,
+	})
+	t.Run("Message is sent and charged", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Equal(t, 6, resp.Balance)
+		assert.Equal(t, 6, srv.Balance())
+	})
+
+	_, err = c.SendMessage(termii.SendMessageRequest{
+		To: "2347880234567", From: "Acme", Sms: strings.Repeat("a", 200), Type: termii.SMSTypePlain, Channel: termii.ChannelGeneric,
+	})
+	t.Run("Message the balance does not cover is refused", func(t *testing.T) {
+		assert.True(t, termii.IsInsufficientBalance(err))
+		assert.Equal(t, 6, srv.Balance())
+	})
+
+	history, err := c.GetHistory()
+	t.Run("Sent messages are in the history", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Len(t, history, 1)
+		assert.Equal(t, resp.MessageID, history[0].MessageID)
+		assert.Equal(t, "Hello", history[0].Message)
+	})
+
+	srv.SetMessageStatus(resp.MessageID, termii.MessageStatusDelivered)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	status, err := c.WaitForDelivery(ctx, resp.MessageID, 10*time.Millisecond)
+	t.Run("Message status is reported", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Equal(t, termii.MessageStatusDelivered, status.Status)
+	})
+
+	balance, err := c.GetBalance()
+	t.Run("Balance is reported", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Equal(t, termii.GetBalanceResponse{User: termiitest.DefaultUser, Balance: 6, Currency: "NGN"}, balance)
+	})
+}
+
+func TestTokens(t *testing.T) {
+	srv := termiitest.NewServer()
+	defer srv.Close()
+	c := srv.Client()
+
+	sent, err := c.SendToken(tokenRequest("2348109077743"))
+	pin, found := srv.LastPin("2348109077743")
+	t.Run("Pin is issued", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, sent.PinID, pin.ID)
+		assert.Len(t, pin.Pin, 6)
+		assert.Equal(t, "Your pin is "+pin.Pin, srv.Messages()[0].Message)
+	})
+
+	verified, err := c.VerifyToken(termii.VerifyTokenRequest{PinID: sent.PinID, Pin: "wrong!"})
+	t.Run("Wrong pin is not verified", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Equal(t, "False", verified.Verified)
+	})
+
+	verified, err = c.VerifyToken(termii.VerifyTokenRequest{PinID: sent.PinID, Pin: pin.Pin})
+	t.Run("Right pin is verified", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Equal(t, "True", verified.Verified)
+	})
+
+	_, err = c.VerifyToken(termii.VerifyTokenRequest{PinID: sent.PinID, Pin: pin.Pin})
+	t.Run("Pin can only be verified once", func(t *testing.T) {
+		assert.Error(t, err)
+	})
+
+	sent, _ = c.SendToken(tokenRequest("2348109077743"))
+	c.VerifyToken(termii.VerifyTokenRequest{PinID: sent.PinID, Pin: "wrong!"})
+	c.VerifyToken(termii.VerifyTokenRequest{PinID: sent.PinID, Pin: "wrong!"})
+	pin, _ = srv.LastPin("2348109077743")
+	_, err = c.VerifyToken(termii.VerifyTokenRequest{PinID: sent.PinID, Pin: pin.Pin})
+	t.Run("Pin is locked once its attempts are used", func(t *testing.T) {
+		assert.Error(t, err)
+		assert.Equal(t, 0, pin.AttemptsLeft)
+	})
+
+	sent, _ = c.SendToken(tokenRequest("2348109077743"))
+	pin, _ = srv.LastPin("2348109077743")
+	srv.Advance(6 * time.Minute)
+	_, err = c.VerifyToken(termii.VerifyTokenRequest{PinID: sent.PinID, Pin: pin.Pin})
+	t.Run("Pin expires after its time to live", func(t *testing.T) {
+		apiErr, ok := termii.AsAPIError(err)
+		assert.True(t, ok)
+		assert.Equal(t, "Pin expired", apiErr.Message)
+	})
+
+	_, err = c.VerifyToken(termii.VerifyTokenRequest{PinID: "29ae67c2-c8e1-4165-8a51-8d3d7c298081", Pin: "123456"})
+	t.Run("Unknown pin is not found", func(t *testing.T) {
+		assert.True(t, termii.IsNotFound(err))
+	})
+
+	generated, err := c.GetInAppToken(termii.GenerateTokenRequest{
+		PhoneNumber: "2348109077743", PinType: termii.PinTypeAlphanumeric, PinAttempts: 3, PinTimeToLive: 5, PinLength: 8,
+	})
+	verified, _ = c.VerifyToken(termii.VerifyTokenRequest{PinID: generated.Data.PinID, Pin: generated.Data.Otp})
+	t.Run("In app token is verified", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Len(t, generated.Data.Otp, 8)
+		assert.Equal(t, "True", verified.Verified)
+	})
+}
+
+func TestFaultInjection(t *testing.T) {
+	srv := termiitest.NewServer()
+	defer srv.Close()
+
+	_, err := srv.Client(termii.WithAPIKey("stolen-key")).GetBalance()
+	t.Run("Unknown api key is unauthorized", func(t *testing.T) {
+		assert.True(t, termii.IsUnauthorized(err))
+	})
+
+	c := srv.Client(termii.WithRetryPolicy(termii.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}))
+
+	srv.InjectError(termii.EndpointGetBalance, http.StatusServiceUnavailable, "Service unavailable", 2)
+	_, err = c.GetBalance()
+	t.Run("Injected errors are retried", func(t *testing.T) {
+		assert.NoError(t, err)
+	})
+
+	srv.InjectError(termii.EndpointGetBalance, http.StatusServiceUnavailable, "Service unavailable", 3)
+	_, err = c.GetBalance()
+	t.Run("Injected errors are returned", func(t *testing.T) {
+		assert.Equal(t, 3, termii.RetryAttempts(err))
+		apiErr, _ := termii.AsAPIError(err)
+		assert.Equal(t, "Service unavailable", apiErr.Message)
+	})
+
+	srv.SetRateLimit(1, time.Minute)
+	_, err = srv.Client().GetBalance()
+	assert.NoError(t, err)
+	_, err = srv.Client().GetBalance()
+	t.Run("Requests over the rate limit are throttled", func(t *testing.T) {
+		assert.True(t, termii.IsRateLimited(err))
+		apiErr, _ := termii.AsAPIError(err)
+		assert.True(t, apiErr.RetryAfter > 0)
+	})
+	srv.SetRateLimit(0, 0)
+
+	srv.InjectLatency(time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = srv.Client().GetBalanceWithContext(ctx)
+	t.Run("Latency is injected", func(t *testing.T) {
+		assert.Error(t, err)
+	})
+}
+
+func TestSenderIDs(t *testing.T) {
+	srv := termiitest.NewServer()
+	defer srv.Close()
+	for i := 0; i < 20; i++ {
+		c := srv.Client(termii.WithSenderID(fmt.Sprintf("Acme%d", i)))
+		_, err := c.RegisterSender(termii.RegisterSenderIdRequest{Usecase: "Your pin is 1234", Company: "Acme"})
+		assert.NoError(t, err)
+	}
+	srv.SetSenderIDStatus("Acme0", "active")
+
+	senderIDs, err := srv.Client().IterateSenderIDs(0).CollectAll(context.Background())
+	t.Run("Sender ids are paginated", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Len(t, senderIDs, 20)
+		assert.Equal(t, "active", senderIDs[0].Status)
+		assert.Equal(t, "pending", senderIDs[19].Status)
+	})
+}
+
+func TestPhonebooksAndCampaigns(t *testing.T) {
+	srv := termiitest.NewServer()
+	defer srv.Close()
+	c := srv.Client()
+
+	_, err := c.CreatePhonebook(termii.PhonebookRequest{PhonebookName: "Customers", Description: "Paying customers"})
+	assert.NoError(t, err)
+	phonebooks, err := c.ListPhonebooks()
+	assert.NoError(t, err)
+	pbID := phonebooks.Data[0].ID
+
+	_, err = c.AddContact(pbID, termii.AddContactRequest{PhoneNumber: "08031234567", CountryCode: "234", FirstName: "Ada"})
+	assert.NoError(t, err)
+	_, err = c.UploadContactsCSV(pbID, "234", strings.NewReader("phone_number,first_name\n08057654321,Bola\n2348109077743,Chi\n"))
+	assert.NoError(t, err)
+
+	contacts, err := c.ListContacts(pbID)
+	t.Run("Contacts are added and uploaded", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Equal(t, 3, contacts.Meta.Total)
+		assert.Equal(t, "2348031234567", contacts.Data[0].PhoneNumber)
+		assert.Equal(t, "Bola", contacts.Data[1].FirstName)
+	})
+
+	sent, err := c.SendCampaign(termii.SendCampaignRequest{
+		CountryCode: "234", SenderID: "Acme", Message: "Sale!", Channel: termii.ChannelGeneric, MessageType: "plain", PhonebookID: pbID,
+	})
+	history, _ := c.GetCampaignHistory(sent.CampaignID)
+	t.Run("Campaign is sent to every contact", func(t *testing.T) {
+		assert.NoError(t, err)
+		assert.Len(t, history.Data, 3)
+		assert.Equal(t, termiitest.DefaultBalance-3*termiitest.DefaultPageCost, srv.Balance())
+	})
+
+	_, err = c.DeleteContact(pbID, fmt.Sprint(contacts.Data[0].ID))
+	assert.NoError(t, err)
+	_, err = c.DeletePhonebook(pbID)
+	assert.NoError(t, err)
+	_, err = c.ListContacts(pbID)
+	t.Run("Deleted phonebook is not found", func(t *testing.T) {
+		assert.True(t, termii.IsNotFound(err))
+	})
+}
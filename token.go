@@ -1,24 +1,29 @@
 package gotermii
 
 import (
+	"context"
 	"net/http"
+	"strings"
 
 	"github.com/pkg/errors"
 )
 
 // SendTokenRequest is a representation of a send token request
 type SendTokenRequest struct {
-	APIKey         string `json:"api_key"`
-	MessageType    string `json:"message_type"`
-	To             string `json:"to"`
-	From           string `json:"from"`
-	Channel        string `json:"channel"`
-	PinAttempts    int    `json:"pin_attempts"`
-	PinTimeToLive  int    `json:"pin_time_to_live"`
-	PinLength      int    `json:"pin_length"`
-	PinPlaceholder string `json:"pin_placeholder"`
-	MessageText    string `json:"message_text"`
-	PinType        string `json:"pin_type"`
+	APIKey         string      `json:"api_key"`
+	MessageType    MessageType `json:"message_type"`
+	To             string      `json:"to"`
+	From           string      `json:"from"`
+	Channel        Channel     `json:"channel"`
+	PinAttempts    int         `json:"pin_attempts"`
+	PinTimeToLive  int         `json:"pin_time_to_live"`
+	PinLength      int         `json:"pin_length"`
+	PinPlaceholder string      `json:"pin_placeholder"`
+	MessageText    string      `json:"message_text"`
+	PinType        PinType     `json:"pin_type"`
+	// EmailAddress and EmailConfigurationID are used when Channel is email
+	EmailAddress         string `json:"email_address,omitempty"`
+	EmailConfigurationID string `json:"email_configuration_id,omitempty"`
 }
 
 // VerifyTokenRequest is a representation of a verify token request
@@ -30,12 +35,12 @@ type VerifyTokenRequest struct {
 
 // GenerateTokenRequest is a representation of a generate in app token request
 type GenerateTokenRequest struct {
-	APIKey        string `json:"api_key"`
-	PinType       string `json:"pin_type"`
-	PhoneNumber   string `json:"phone_number"`
-	PinAttempts   int    `json:"pin_attempts"`
-	PinTimeToLive int    `json:"pin_time_to_live"`
-	PinLength     int    `json:"pin_length"`
+	APIKey        string  `json:"api_key"`
+	PinType       PinType `json:"pin_type"`
+	PhoneNumber   string  `json:"phone_number"`
+	PinAttempts   int     `json:"pin_attempts"`
+	PinTimeToLive int     `json:"pin_time_to_live"`
+	PinLength     int     `json:"pin_length"`
 }
 
 // VerifyTokenResponse is a representation of a verify token response
@@ -45,6 +50,17 @@ type VerifyTokenResponse struct {
 	Msisdn   string      `json:"msisdn"`
 }
 
+// IsVerified reports whether termii verified the pin, it reports verified as either true or "True"
+func (r VerifyTokenResponse) IsVerified() bool {
+	switch v := r.Verified.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	}
+	return false
+}
+
 // SendTokenResponse is a representation of a send token response
 type SendTokenResponse struct {
 	PinID     string `json:"pinId"`
@@ -68,23 +84,42 @@ type GenerateTokenResponse struct {
 
 // SendToken sends a token request
 func (c Client) SendToken(req SendTokenRequest) (SendTokenResponse, error) {
+	return c.SendTokenWithContext(context.Background(), req)
+}
+
+// SendTokenWithContext is like SendToken but carries ctx through to the underlying http request
+func (c Client) SendTokenWithContext(ctx context.Context, req SendTokenRequest) (SendTokenResponse, error) {
 	req.APIKey = c.config.APIKey
-	rURL := "api/sms/otp/send"
+	req.Channel = req.Channel.canonical()
+	rURL := string(EndpointSendToken)
+	if err := c.normalizePhone("SendTokenRequest", "to", &req.To, ""); err != nil {
+		return SendTokenResponse{}, err
+	}
 
 	var tokenResponse SendTokenResponse
-	if err := c.makeRequest(http.MethodPost, rURL, req, &tokenResponse); err != nil {
+	if err := c.makeRequest(ctx, http.MethodPost, EndpointSendToken, rURL, req, &tokenResponse); err != nil {
 		return SendTokenResponse{}, errors.Wrap(err, "error in making request to send otp token")
 	}
+	c.messagesSent(ctx, string(req.Channel), 1)
 	return tokenResponse, nil
 }
 
-// VerifyToken sends a request to verify token
+// VerifyToken sends a request to verify token. It verifies pins issued by SendToken, over any of
+// its channels including email, and by SendVoiceToken. Codes sent with SendEmailToken and
+// SendVoiceCall are generated, and so verified, by the caller.
 func (c Client) VerifyToken(req VerifyTokenRequest) (VerifyTokenResponse, error) {
+	return c.VerifyTokenWithContext(context.Background(), req)
+}
+
+// VerifyTokenWithContext is like VerifyToken but carries ctx through to the underlying http request
+func (c Client) VerifyTokenWithContext(ctx context.Context, req VerifyTokenRequest) (VerifyTokenResponse, error) {
 	req.APIKey = c.config.APIKey
-	rURL := "api/sms/otp/verify"
+	rURL := string(EndpointVerifyToken)
 
 	var tokenResponse VerifyTokenResponse
-	if err := c.makeRequest(http.MethodPost, rURL, req, &tokenResponse); err != nil {
+	err := c.makeRequest(ctx, http.MethodPost, EndpointVerifyToken, rURL, req, &tokenResponse)
+	c.tokenVerified(ctx, tokenResponse, err)
+	if err != nil {
 		return VerifyTokenResponse{}, errors.Wrap(err, "error in making request to verify otp token")
 	}
 	return tokenResponse, nil
@@ -92,11 +127,16 @@ func (c Client) VerifyToken(req VerifyTokenRequest) (VerifyTokenResponse, error)
 
 // GetInAppToken sends a request to get in app token
 func (c Client) GetInAppToken(req GenerateTokenRequest) (GenerateTokenResponse, error) {
+	return c.GetInAppTokenWithContext(context.Background(), req)
+}
+
+// GetInAppTokenWithContext is like GetInAppToken but carries ctx through to the underlying http request
+func (c Client) GetInAppTokenWithContext(ctx context.Context, req GenerateTokenRequest) (GenerateTokenResponse, error) {
 	req.APIKey = c.config.APIKey
-	rURL := "api/sms/otp/generate"
+	rURL := string(EndpointGetInAppToken)
 
 	var tokenResponse GenerateTokenResponse
-	if err := c.makeRequest(http.MethodPost, rURL, req, &tokenResponse); err != nil {
+	if err := c.makeRequest(ctx, http.MethodPost, EndpointGetInAppToken, rURL, req, &tokenResponse); err != nil {
 		return GenerateTokenResponse{}, errors.Wrap(err, "error in making request to generate token")
 	}
 	return tokenResponse, nil
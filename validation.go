@@ -0,0 +1,291 @@
+package gotermii
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+// Limits enforced on requests before they are sent to termii
+const (
+	MinPinLength     = 4
+	MaxPinLength     = 8
+	MaxPinTimeToLive = 60
+	// MaxSMSLength is the number of characters that fit in 6 concatenated GSM-7 pages
+	MaxSMSLength = 918
+	// MaxSenderIDLength is the maximum number of characters of a sender id
+	MaxSenderIDLength = 11
+)
+
+// FieldError is a representation of a single invalid field of a request
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Message)
+}
+
+// ValidationError is returned when a request is rejected before being sent to termii,
+// it lists every invalid field of the request
+type ValidationError struct {
+	Request string
+	Fields  []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Error()
+	}
+	return fmt.Sprintf("invalid %s: %s", e.Request, strings.Join(msgs, "; "))
+}
+
+// AsValidationError returns the ValidationError wrapped in err, if any
+func AsValidationError(err error) (*ValidationError, bool) {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr, true
+	}
+	return nil, false
+}
+
+// validatable is implemented by requests that can be checked before they are sent
+type validatable interface {
+	Validate() error
+}
+
+// validator collects the field errors of a request
+type validator struct {
+	request string
+	fields  []FieldError
+}
+
+func (v *validator) addf(field, format string, args ...interface{}) {
+	v.fields = append(v.fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.addf(field, "is required")
+	}
+}
+
+func (v *validator) between(field string, value, min, max int) {
+	if value < min || value > max {
+		v.addf(field, "must be between %d and %d, got %d", min, max, value)
+	}
+}
+
+// enum requires value to be set and known
+func (v *validator) enum(field, value string, valid bool) {
+	switch {
+	case value == "":
+		v.addf(field, "is required")
+	case !valid:
+		v.addf(field, "has unknown value %q", value)
+	}
+}
+
+func (v *validator) maxLength(field, value string, max int) {
+	if n := utf8.RuneCountInString(value); n > max {
+		v.addf(field, "must be at most %d characters, got %d", max, n)
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Request: v.request, Fields: v.fields}
+}
+
+// pin checks the attempts, time to live and length shared by token requests
+func (v *validator) pin(attempts, timeToLive, length int) {
+	if attempts < 1 {
+		v.addf("pin_attempts", "must be at least 1, got %d", attempts)
+	}
+	v.between("pin_time_to_live", timeToLive, 0, MaxPinTimeToLive)
+	v.between("pin_length", length, MinPinLength, MaxPinLength)
+}
+
+// Validate checks the request against termii's documented constraints
+func (req SendMessageRequest) Validate() error {
+	v := validator{request: "SendMessageRequest"}
+	req.Channel = req.Channel.canonical()
+	v.required("to", req.To)
+	v.required("from", req.From)
+	v.required("sms", req.Sms)
+	v.enum("type", string(req.Type), req.Type.Valid())
+	v.enum("channel", string(req.Channel), req.Channel.Valid())
+	if req.Channel != ChannelWhatsApp {
+		v.maxLength("sms", req.Sms, MaxSMSLength)
+	}
+	return v.err()
+}
+
+// Validate checks the request against termii's documented constraints
+func (req BulkMessageRequest) Validate() error {
+	v := validator{request: "BulkMessageRequest"}
+	req.Channel = req.Channel.canonical()
+	if len(req.To) == 0 {
+		v.addf("to", "requires at least one recipient")
+	}
+	for i, to := range req.To {
+		v.required(fmt.Sprintf("to[%d]", i), to)
+	}
+	v.required("from", req.From)
+	v.required("sms", req.Sms)
+	if req.Channel != ChannelWhatsApp {
+		v.maxLength("sms", req.Sms, MaxSMSLength)
+	}
+	v.enum("type", string(req.Type), req.Type.Valid())
+	v.enum("channel", string(req.Channel), req.Channel.Valid())
+	return v.err()
+}
+
+// Validate checks the request against termii's documented constraints
+func (req AutoGeneratedMessageRequest) Validate() error {
+	v := validator{request: "AutoGeneratedMessageRequest"}
+	v.required("to", req.To)
+	v.required("sms", req.Sms)
+	v.maxLength("sms", req.Sms, MaxSMSLength)
+	return v.err()
+}
+
+// Validate checks the request against termii's documented constraints
+func (req TemplateRequest) Validate() error {
+	v := validator{request: "TemplateRequest"}
+	v.required("phone_number", req.PhoneNumber)
+	v.required("device_id", req.DeviceID)
+	v.required("template_id", req.TemplateID)
+	return v.err()
+}
+
+// Validate checks the request against termii's documented constraints
+func (req RegisterSenderIdRequest) Validate() error {
+	v := validator{request: "RegisterSenderIdRequest"}
+	v.required("sender_id", req.SenderID)
+	v.maxLength("sender_id", req.SenderID, MaxSenderIDLength)
+	v.required("usecase", req.Usecase)
+	v.required("company", req.Company)
+	return v.err()
+}
+
+// Validate checks the request against termii's documented constraints
+func (req SendTokenRequest) Validate() error {
+	v := validator{request: "SendTokenRequest"}
+	req.Channel = req.Channel.canonical()
+	if req.Channel == ChannelEmail {
+		v.required("email_address", req.EmailAddress)
+		v.required("email_configuration_id", req.EmailConfigurationID)
+	} else {
+		v.required("to", req.To)
+	}
+	v.required("from", req.From)
+	v.enum("channel", string(req.Channel), req.Channel.Valid())
+	v.enum("message_type", string(req.MessageType), req.MessageType.Valid())
+	v.enum("pin_type", string(req.PinType), req.PinType.Valid())
+	v.pin(req.PinAttempts, req.PinTimeToLive, req.PinLength)
+	v.required("pin_placeholder", req.PinPlaceholder)
+	v.required("message_text", req.MessageText)
+	if req.PinPlaceholder != "" && req.MessageText != "" && !strings.Contains(req.MessageText, req.PinPlaceholder) {
+		v.addf("message_text", "must contain the pin placeholder %q", req.PinPlaceholder)
+	}
+	v.maxLength("message_text", req.MessageText, MaxSMSLength)
+	return v.err()
+}
+
+// Validate checks the request against termii's documented constraints
+func (req VerifyTokenRequest) Validate() error {
+	v := validator{request: "VerifyTokenRequest"}
+	v.required("pin_id", req.PinID)
+	v.required("pin", req.Pin)
+	return v.err()
+}
+
+// Validate checks the request against termii's documented constraints
+func (req GenerateTokenRequest) Validate() error {
+	v := validator{request: "GenerateTokenRequest"}
+	v.required("phone_number", req.PhoneNumber)
+	v.enum("pin_type", string(req.PinType), req.PinType.Valid())
+	v.pin(req.PinAttempts, req.PinTimeToLive, req.PinLength)
+	return v.err()
+}
+
+// Validate checks the request against termii's documented constraints
+func (req VoiceTokenRequest) Validate() error {
+	v := validator{request: "VoiceTokenRequest"}
+	v.required("phone_number", req.PhoneNumber)
+	v.pin(req.PinAttempts, req.PinTimeToLive, req.PinLength)
+	return v.err()
+}
+
+// Validate checks the request against termii's documented constraints
+func (req VoiceCallRequest) Validate() error {
+	v := validator{request: "VoiceCallRequest"}
+	v.required("phone_number", req.PhoneNumber)
+	if req.Code < 1000 || req.Code > 99999999 {
+		v.addf("code", "must be numeric with %d to %d digits, got %d", MinPinLength, MaxPinLength, req.Code)
+	}
+	return v.err()
+}
+
+// Validate checks the request against termii's documented constraints
+func (req EmailTokenRequest) Validate() error {
+	v := validator{request: "EmailTokenRequest"}
+	v.required("email_address", req.EmailAddress)
+	if req.EmailAddress != "" && !strings.Contains(req.EmailAddress, "@") {
+		v.addf("email_address", "must be an email address, got %q", req.EmailAddress)
+	}
+	v.required("code", req.Code)
+	v.required("email_configuration_id", req.EmailConfigurationID)
+	return v.err()
+}
+
+// Validate checks the request against termii's documented constraints
+func (req VerifyNumberRequest) Validate() error {
+	v := validator{request: "VerifyNumberRequest"}
+	v.required("phone_number", req.PhoneNumber)
+	return v.err()
+}
+
+// Validate checks the request against termii's documented constraints
+func (req StatusRequest) Validate() error {
+	v := validator{request: "StatusRequest"}
+	v.required("phone_number", req.PhoneNumber)
+	v.required("country_code", req.CountryCode)
+	return v.err()
+}
+
+// Validate checks the request against termii's documented constraints
+func (req PhonebookRequest) Validate() error {
+	v := validator{request: "PhonebookRequest"}
+	v.required("phonebook_name", req.PhonebookName)
+	return v.err()
+}
+
+// Validate checks the request against termii's documented constraints
+func (req AddContactRequest) Validate() error {
+	v := validator{request: "AddContactRequest"}
+	v.required("phone_number", req.PhoneNumber)
+	return v.err()
+}
+
+// Validate checks the request against termii's documented constraints
+func (req SendCampaignRequest) Validate() error {
+	v := validator{request: "SendCampaignRequest"}
+	v.required("country_code", req.CountryCode)
+	v.required("sender_id", req.SenderID)
+	v.required("message", req.Message)
+	v.enum("channel", string(req.Channel), req.Channel.Valid())
+	v.required("message_type", req.MessageType)
+	v.required("phonebook_id", req.PhonebookID)
+	if req.CampaignType != "" && req.CampaignType != CampaignTypeRegular && req.CampaignType != CampaignTypePersonalized {
+		v.addf("campaign_type", "has unknown value %q", req.CampaignType)
+	}
+	return v.err()
+}
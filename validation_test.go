@@ -0,0 +1,86 @@
+package gotermii_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	termii "github.com/Uchencho/go-termii"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func invalidFields(err error) []string {
+	validationErr, ok := termii.AsValidationError(err)
+	if !ok {
+		return nil
+	}
+	var fields []string
+	for _, f := range validationErr.Fields {
+		fields = append(fields, f.Field)
+	}
+	return fields
+}
+
+func TestRequestValidation(t *testing.T) {
+	termiiService := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		t.Error("invalid request should not reach termii")
+	}))
+	defer termiiService.Close()
+
+	c, _ := termii.New(termii.WithAPIKey(termiiTestApiKey), termii.WithBaseURL(termiiService.URL))
+
+	_, err := c.SendToken(termii.SendTokenRequest{
+		To:             "2348109077743",
+		From:           "Acme",
+		Channel:        termii.ChannelDND,
+		MessageType:    termii.MessageTypeNumeric,
+		PinType:        termii.PinTypeNumeric,
+		PinAttempts:    0,
+		PinTimeToLive:  90,
+		PinLength:      2,
+		PinPlaceholder: "< 1234 >",
+		MessageText:    "Your pin is 1234",
+	})
+	t.Run("Every invalid token field is reported", func(t *testing.T) {
+		assert.Equal(t, []string{"pin_attempts", "pin_time_to_live", "pin_length", "message_text"}, invalidFields(err))
+	})
+
+	_, err = c.SendMessage(termii.SendMessageRequest{
+		To:      "2347880234567",
+		Sms:     strings.Repeat("a", termii.MaxSMSLength+1),
+		Type:    termii.SMSTypePlain,
+		Channel: termii.ChannelGeneric,
+	})
+	t.Run("Missing sender and long sms are reported", func(t *testing.T) {
+		assert.Equal(t, []string{"from", "sms"}, invalidFields(err))
+	})
+
+	_, err = c.VerifyToken(termii.VerifyTokenRequest{PinID: "29ae67c2-c8e1-4165-8a51-8d3d7c298081"})
+	t.Run("Missing pin is reported", func(t *testing.T) {
+		assert.Equal(t, []string{"pin"}, invalidFields(err))
+		assert.Contains(t, err.Error(), "invalid VerifyTokenRequest: pin is required")
+	})
+
+	_, err = c.GetStatus(termii.StatusRequest{})
+	t.Run("Missing status fields are reported", func(t *testing.T) {
+		assert.Equal(t, []string{"phone_number", "country_code"}, invalidFields(err))
+	})
+
+	_, err = c.SendBulkMessage(termii.BulkMessageRequest{To: []string{"2347880234567", ""}, From: "Acme", Sms: "Hi", Type: termii.SMSTypePlain, Channel: termii.ChannelGeneric})
+	t.Run("Empty bulk recipient is reported", func(t *testing.T) {
+		assert.Equal(t, []string{"to[1]"}, invalidFields(err))
+	})
+
+	long := strings.Repeat("a", termii.MaxSMSLength+1)
+	t.Run("Long whatsapp messages are accepted by single and bulk requests", func(t *testing.T) {
+		assert.NoError(t, termii.SendMessageRequest{To: "2347880234567", From: "Acme", Sms: long, Type: termii.SMSTypePlain, Channel: "WhatsApp"}.Validate())
+		assert.NoError(t, termii.BulkMessageRequest{To: []string{"2347880234567"}, From: "Acme", Sms: long, Type: termii.SMSTypePlain, Channel: "WhatsApp"}.Validate())
+	})
+
+	t.Run("Long bulk sms is reported", func(t *testing.T) {
+		err := termii.BulkMessageRequest{To: []string{"2347880234567"}, From: "Acme", Sms: long, Type: termii.SMSTypePlain, Channel: termii.ChannelGeneric}.Validate()
+		assert.Equal(t, []string{"sms"}, invalidFields(err))
+	})
+}
@@ -0,0 +1,82 @@
+package gotermii
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// VoiceTokenRequest is a representation of a send voice token request
+type VoiceTokenRequest struct {
+	APIKey        string `json:"api_key"`
+	PhoneNumber   string `json:"phone_number"`
+	PinAttempts   int    `json:"pin_attempts"`
+	PinTimeToLive int    `json:"pin_time_to_live"`
+	PinLength     int    `json:"pin_length"`
+}
+
+// VoiceTokenResponse is a representation of a send voice token response
+type VoiceTokenResponse struct {
+	Code      string      `json:"code"`
+	PinID     string      `json:"pinId"`
+	MessageID string      `json:"message_id"`
+	Message   string      `json:"message"`
+	Balance   interface{} `json:"balance"`
+	User      string      `json:"user"`
+}
+
+// VoiceCallRequest is a representation of a send voice call request. Code is the numeric
+// code, between 4 and 8 digits, read out to the recipient.
+type VoiceCallRequest struct {
+	APIKey      string `json:"api_key"`
+	PhoneNumber string `json:"phone_number"`
+	Code        int    `json:"code"`
+}
+
+// VoiceCallResponse is a representation of a send voice call response
+type VoiceCallResponse struct {
+	Code      string      `json:"code"`
+	MessageID string      `json:"message_id"`
+	Message   string      `json:"message"`
+	Balance   interface{} `json:"balance"`
+	User      string      `json:"user"`
+}
+
+// SendVoiceToken sends an otp to the recipient through a voice call, it is verified with VerifyToken.
+// See docs https://developers.termii.com/voice-token for more details
+func (c Client) SendVoiceToken(req VoiceTokenRequest) (VoiceTokenResponse, error) {
+	return c.SendVoiceTokenWithContext(context.Background(), req)
+}
+
+// SendVoiceTokenWithContext is like SendVoiceToken but carries ctx through to the underlying http request
+func (c Client) SendVoiceTokenWithContext(ctx context.Context, req VoiceTokenRequest) (VoiceTokenResponse, error) {
+	req.APIKey = c.config.APIKey
+	rURL := string(EndpointSendVoiceToken)
+
+	var tokenResponse VoiceTokenResponse
+	if err := c.makeRequest(ctx, http.MethodPost, EndpointSendVoiceToken, rURL, req, &tokenResponse); err != nil {
+		return VoiceTokenResponse{}, errors.Wrap(err, "error in making request to send voice token")
+	}
+	c.messagesSent(ctx, InstrumentChannelVoice, 1)
+	return tokenResponse, nil
+}
+
+// SendVoiceCall reads out a code generated by the caller to the recipient through a voice call.
+// See docs https://developers.termii.com/voice-call for more details
+func (c Client) SendVoiceCall(req VoiceCallRequest) (VoiceCallResponse, error) {
+	return c.SendVoiceCallWithContext(context.Background(), req)
+}
+
+// SendVoiceCallWithContext is like SendVoiceCall but carries ctx through to the underlying http request
+func (c Client) SendVoiceCallWithContext(ctx context.Context, req VoiceCallRequest) (VoiceCallResponse, error) {
+	req.APIKey = c.config.APIKey
+	rURL := string(EndpointSendVoiceCall)
+
+	var callResponse VoiceCallResponse
+	if err := c.makeRequest(ctx, http.MethodPost, EndpointSendVoiceCall, rURL, req, &callResponse); err != nil {
+		return VoiceCallResponse{}, errors.Wrap(err, "error in making request to send voice call")
+	}
+	c.messagesSent(ctx, InstrumentChannelVoice, 1)
+	return callResponse, nil
+}
@@ -0,0 +1,97 @@
+package webhook
+
+import (
+	"encoding/json"
+	"strings"
+
+	termii "github.com/Uchencho/go-termii"
+)
+
+// EventType is a representation of the kind of event termii posts to a webhook
+type EventType string
+
+// Events dispatched by a Handler
+const (
+	EventDelivery      EventType = "delivery"
+	EventInboundSMS    EventType = "inbound"
+	EventWhatsAppReply EventType = "whatsapp"
+)
+
+// eventTypes maps the type field of termii's payloads to the event they carry. Delivery reports are
+// typed by the channel the message was sent over, so whatsapp payloads are told apart by envelope.
+var eventTypes = map[string]EventType{
+	"delivery":         EventDelivery,
+	"dlr":              EventDelivery,
+	"outbound":         EventDelivery,
+	"sms":              EventDelivery,
+	"voice":            EventDelivery,
+	"inbound":          EventInboundSMS,
+	"inbound_sms":      EventInboundSMS,
+	"whatsapp_reply":   EventWhatsAppReply,
+	"inbound_whatsapp": EventWhatsAppReply,
+}
+
+// DeliveryEvent is a representation of the delivery report of a message
+type DeliveryEvent struct {
+	Type      string      `json:"type"`
+	ID        string      `json:"id"`
+	MessageID string      `json:"message_id"`
+	Receiver  string      `json:"receiver"`
+	Sender    string      `json:"sender"`
+	Message   string      `json:"message"`
+	SentAt    string      `json:"sent_at"`
+	Cost      json.Number `json:"cost"`
+	Status    string      `json:"status"`
+	Channel   string      `json:"channel"`
+}
+
+// Delivered reports whether the message was delivered to the receiver
+func (e DeliveryEvent) Delivered() bool {
+	return strings.EqualFold(strings.TrimSpace(e.Status), termii.MessageStatusDelivered)
+}
+
+// InboundSMSEvent is a representation of an sms sent by a customer to one of the business' numbers
+type InboundSMSEvent struct {
+	Type       string `json:"type"`
+	ID         string `json:"id"`
+	Sender     string `json:"sender"`
+	Receiver   string `json:"receiver"`
+	Message    string `json:"message"`
+	ReceivedAt string `json:"received_at"`
+}
+
+// WhatsAppReplyEvent is a representation of a reply sent by a customer over whatsapp
+type WhatsAppReplyEvent struct {
+	Type       string `json:"type"`
+	ID         string `json:"id"`
+	Sender     string `json:"sender"`
+	Receiver   string `json:"receiver"`
+	Message    string `json:"message"`
+	MediaURL   string `json:"media_url"`
+	ReceivedAt string `json:"received_at"`
+	// InReplyTo is the id of the message the customer replied to, if any
+	InReplyTo string `json:"in_reply_to"`
+}
+
+// envelope holds the fields of a payload that tell its event apart
+type envelope struct {
+	Type       string `json:"type"`
+	MessageID  string `json:"message_id"`
+	Status     string `json:"status"`
+	InReplyTo  string `json:"in_reply_to"`
+	ReceivedAt string `json:"received_at"`
+}
+
+// eventType returns the event carried by the payload. A whatsapp payload is the delivery report of a
+// message sent over whatsapp when it carries the status of a message rather than a received message.
+func (e envelope) eventType() (EventType, bool) {
+	t := strings.ToLower(strings.TrimSpace(e.Type))
+	if t == "whatsapp" {
+		if e.InReplyTo == "" && e.ReceivedAt == "" && (e.MessageID != "" || e.Status != "") {
+			return EventDelivery, true
+		}
+		return EventWhatsAppReply, true
+	}
+	et, ok := eventTypes[t]
+	return et, ok
+}
@@ -0,0 +1,281 @@
+// Package webhook receives the delivery reports and inbound messages termii posts to a webhook url.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// SignatureHeader is the header carrying the hex encoded HMAC-SHA512 of the payload, keyed by the secret key
+const SignatureHeader = "X-Termii-Signature"
+
+// Defaults of a Handler
+const (
+	DefaultReplayWindow = 24 * time.Hour
+	DefaultMaxBodySize  = 1 << 20
+)
+
+// ErrNoSecretKey is returned by NewHandler when the secret key is empty, anyone can sign payloads with an empty key
+var ErrNoSecretKey = errors.New("webhook - secret key is required")
+
+// Errors reported to the error handler of a Handler
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrReplayed         = errors.New("webhook payload replayed")
+	ErrMalformedPayload = errors.New("malformed webhook payload")
+)
+
+// ReplayCache remembers the signatures of payloads that have been handled. An in memory cache is used by
+// default, a shared cache should be set with WithReplayCache when the handler runs on several instances.
+type ReplayCache interface {
+	// Add records key for ttl and reports whether it was not already recorded
+	Add(key string, ttl time.Duration) bool
+	// Remove forgets key, so a payload that failed to be handled can be redelivered
+	Remove(key string)
+}
+
+// Option is a representation of a functional option used to configure a Handler
+type Option func(*Handler)
+
+// WithReplayWindow sets how long a payload is remembered, a payload received again within it is rejected
+func WithReplayWindow(window time.Duration) Option {
+	return func(h *Handler) {
+		h.replayWindow = window
+	}
+}
+
+// WithReplayCache sets the cache used to detect replayed payloads
+func WithReplayCache(cache ReplayCache) Option {
+	return func(h *Handler) {
+		h.replays = cache
+	}
+}
+
+// WithMaxBodySize sets the size, in bytes, above which payloads are rejected
+func WithMaxBodySize(size int64) Option {
+	return func(h *Handler) {
+		h.maxBodySize = size
+	}
+}
+
+// WithErrorHandler sets a function called with the error of every payload that was rejected or failed to be handled
+func WithErrorHandler(fn func(r *http.Request, err error)) Option {
+	return func(h *Handler) {
+		h.onError = fn
+	}
+}
+
+// Handler is an http.Handler which verifies the payloads posted by termii and dispatches them to the
+// handler registered for their event. Handlers should be registered before the Handler starts serving.
+//
+// Payloads without a valid signature are rejected with 401, replayed payloads with 409, and payloads whose
+// handler returned an error with 500 so that termii redelivers them. Events without a registered handler
+// are acknowledged.
+type Handler struct {
+	secret       []byte
+	replayWindow time.Duration
+	replays      ReplayCache
+	maxBodySize  int64
+	onError      func(r *http.Request, err error)
+
+	onDelivery      func(context.Context, DeliveryEvent) error
+	onInboundSMS    func(context.Context, InboundSMSEvent) error
+	onWhatsAppReply func(context.Context, WhatsAppReplyEvent) error
+}
+
+// NewHandler creates a Handler verifying payloads with secretKey, the secret key of the termii account.
+// ErrNoSecretKey is returned when secretKey is empty.
+func NewHandler(secretKey string, opts ...Option) (*Handler, error) {
+	if strings.TrimSpace(secretKey) == "" {
+		return nil, ErrNoSecretKey
+	}
+	h := &Handler{
+		secret:       []byte(secretKey),
+		replayWindow: DefaultReplayWindow,
+		maxBodySize:  DefaultMaxBodySize,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	if h.replays == nil {
+		h.replays = newMemoryCache()
+	}
+	return h, nil
+}
+
+// OnDelivery registers the handler of delivery reports
+func (h *Handler) OnDelivery(fn func(context.Context, DeliveryEvent) error) {
+	h.onDelivery = fn
+}
+
+// OnInboundSMS registers the handler of inbound sms
+func (h *Handler) OnInboundSMS(fn func(context.Context, InboundSMSEvent) error) {
+	h.onInboundSMS = fn
+}
+
+// OnWhatsAppReply registers the handler of whatsapp replies
+func (h *Handler) OnWhatsAppReply(fn func(context.Context, WhatsAppReplyEvent) error) {
+	h.onWhatsAppReply = fn
+}
+
+// Sign returns the signature termii sends along with payload
+func Sign(secretKey string, payload []byte) string {
+	mac := hmac.New(sha512.New, []byte(secretKey))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the signature of payload, no signature is valid for an empty secret key
+func Verify(secretKey string, payload []byte, signature string) bool {
+	if strings.TrimSpace(secretKey) == "" {
+		return false
+	}
+	got, err := hex.DecodeString(strings.TrimSpace(signature))
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha512.New, []byte(secretKey))
+	mac.Write(payload)
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		h.reject(w, r, http.StatusMethodNotAllowed, errors.Errorf("webhook - method %s not allowed", r.Method))
+		return
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, h.maxBodySize+1))
+	if err != nil {
+		h.reject(w, r, http.StatusBadRequest, errors.Wrap(err, "webhook - unable to read payload"))
+		return
+	}
+	if int64(len(body)) > h.maxBodySize {
+		h.reject(w, r, http.StatusRequestEntityTooLarge, errors.Errorf("webhook - payload larger than %d bytes", h.maxBodySize))
+		return
+	}
+
+	signature := r.Header.Get(SignatureHeader)
+	if !Verify(string(h.secret), body, signature) {
+		h.reject(w, r, http.StatusUnauthorized, ErrInvalidSignature)
+		return
+	}
+
+	key := strings.ToLower(strings.TrimSpace(signature))
+	if !h.replays.Add(key, h.replayWindow) {
+		h.reject(w, r, http.StatusConflict, ErrReplayed)
+		return
+	}
+
+	if err := h.dispatch(r.Context(), body); err != nil {
+		h.replays.Remove(key)
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrMalformedPayload) {
+			status = http.StatusBadRequest
+		}
+		h.reject(w, r, status, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// dispatch parses body into the event it carries and calls the handler registered for it
+func (h *Handler) dispatch(ctx context.Context, body []byte) error {
+	var env envelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		return errors.Wrapf(ErrMalformedPayload, "webhook - %v", err)
+	}
+
+	et, ok := env.eventType()
+	if !ok {
+		// termii may add events this package does not know of yet
+		return nil
+	}
+
+	switch et {
+	case EventDelivery:
+		var event DeliveryEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			return errors.Wrapf(ErrMalformedPayload, "webhook - %v", err)
+		}
+		if h.onDelivery != nil {
+			return h.onDelivery(ctx, event)
+		}
+	case EventInboundSMS:
+		var event InboundSMSEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			return errors.Wrapf(ErrMalformedPayload, "webhook - %v", err)
+		}
+		if h.onInboundSMS != nil {
+			return h.onInboundSMS(ctx, event)
+		}
+	case EventWhatsAppReply:
+		var event WhatsAppReplyEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			return errors.Wrapf(ErrMalformedPayload, "webhook - %v", err)
+		}
+		if h.onWhatsAppReply != nil {
+			return h.onWhatsAppReply(ctx, event)
+		}
+	}
+	return nil
+}
+
+func (h *Handler) reject(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if h.onError != nil {
+		h.onError(r, err)
+	}
+	http.Error(w, http.StatusText(status), status)
+}
+
+// memoryCache is the in memory ReplayCache used by default
+type memoryCache struct {
+	mu        sync.Mutex
+	expires   map[string]time.Time
+	lastSweep time.Time
+}
+
+// sweepInterval is how often expired keys are evicted from a memoryCache
+const sweepInterval = time.Minute
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{expires: make(map[string]time.Time)}
+}
+
+func (c *memoryCache) Add(key string, ttl time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(c.lastSweep) > sweepInterval {
+		for k, exp := range c.expires {
+			if !exp.After(now) {
+				delete(c.expires, k)
+			}
+		}
+		c.lastSweep = now
+	}
+	if exp, ok := c.expires[key]; ok && exp.After(now) {
+		return false
+	}
+	c.expires[key] = now.Add(ttl)
+	return true
+}
+
+func (c *memoryCache) Remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.expires, key)
+}
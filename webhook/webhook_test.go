@@ -0,0 +1,148 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Uchencho/go-termii/webhook"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const secretKey = "termii-secret"
+
+func post(h http.Handler, payload, signature string) int {
+	req := httptest.NewRequest(http.MethodPost, "/termii/events", strings.NewReader(payload))
+	if signature != "" {
+		req.Header.Set(webhook.SignatureHeader, signature)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestHandlerDispatch(t *testing.T) {
+	h, _ := webhook.NewHandler(secretKey)
+
+	var (
+		delivery webhook.DeliveryEvent
+		inbound  webhook.InboundSMSEvent
+		reply    webhook.WhatsAppReplyEvent
+	)
+	h.OnDelivery(func(ctx context.Context, e webhook.DeliveryEvent) error { delivery = e; return nil })
+	h.OnInboundSMS(func(ctx context.Context, e webhook.InboundSMSEvent) error { inbound = e; return nil })
+	h.OnWhatsAppReply(func(ctx context.Context, e webhook.WhatsAppReplyEvent) error { reply = e; return nil })
+
+	payload := `{"type":"sms","id":"3017544054459081329241526","message_id":"3017544054459081329241526",` +
+		`"receiver":"2348753243651","sender":"Acme","message":"Your pin is 4324","sent_at":"2024-01-01 10:00:00",` +
+		`"cost":"1.6","status":"Delivered","channel":"dnd"}`
+	t.Run("Delivery report is dispatched", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, post(h, payload, webhook.Sign(secretKey, []byte(payload))))
+		assert.Equal(t, "3017544054459081329241526", delivery.MessageID)
+		assert.Equal(t, "1.6", delivery.Cost.String())
+		assert.True(t, delivery.Delivered())
+	})
+
+	payload = `{"type":"inbound","id":"101","sender":"2348753243651","receiver":"Acme","message":"STOP","received_at":"2024-01-01 10:00:00"}`
+	t.Run("Inbound sms is dispatched", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, post(h, payload, webhook.Sign(secretKey, []byte(payload))))
+		assert.Equal(t, "STOP", inbound.Message)
+	})
+
+	payload = `{"type":"whatsapp","id":"102","sender":"2348753243651","receiver":"Acme","message":"Yes","in_reply_to":"3017544054459081329241526"}`
+	t.Run("Whatsapp reply is dispatched", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, post(h, payload, webhook.Sign(secretKey, []byte(payload))))
+		assert.Equal(t, "Yes", reply.Message)
+		assert.Equal(t, "3017544054459081329241526", reply.InReplyTo)
+	})
+
+	payload = `{"type":"whatsapp","id":"104","message_id":"3017544054459081329241999","receiver":"2348753243651",` +
+		`"sender":"Acme","message":"Your order has shipped","sent_at":"2024-01-01 10:00:00","cost":"2.5","status":"Delivered","channel":"whatsapp"}`
+	reply = webhook.WhatsAppReplyEvent{}
+	t.Run("Whatsapp delivery report is dispatched as a delivery report", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, post(h, payload, webhook.Sign(secretKey, []byte(payload))))
+		assert.Equal(t, "3017544054459081329241999", delivery.MessageID)
+		assert.Equal(t, "whatsapp", delivery.Channel)
+		assert.True(t, delivery.Delivered())
+		assert.Empty(t, reply.ID)
+	})
+
+	payload = `{"type":"carrier_pigeon","id":"103"}`
+	t.Run("Unknown event is acknowledged", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, post(h, payload, webhook.Sign(secretKey, []byte(payload))))
+	})
+
+	payload = `{"type":`
+	t.Run("Malformed payload is rejected", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, post(h, payload, webhook.Sign(secretKey, []byte(payload))))
+	})
+}
+
+func TestHandlerRejects(t *testing.T) {
+	var errs []error
+	h, _ := webhook.NewHandler(secretKey, webhook.WithErrorHandler(func(r *http.Request, err error) { errs = append(errs, err) }))
+
+	failures := 1
+	var handled int
+	h.OnDelivery(func(ctx context.Context, e webhook.DeliveryEvent) error {
+		if failures > 0 {
+			failures--
+			return errors.New("database unavailable")
+		}
+		handled++
+		return nil
+	})
+
+	payload := `{"type":"sms","message_id":"3017544054459081329241526","status":"Delivered"}`
+	signature := webhook.Sign(secretKey, []byte(payload))
+
+	t.Run("Missing signature is rejected", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, post(h, payload, ""))
+	})
+
+	t.Run("Signature of another secret is rejected", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, post(h, payload, webhook.Sign("other-secret", []byte(payload))))
+	})
+
+	t.Run("Tampered payload is rejected", func(t *testing.T) {
+		assert.Equal(t, http.StatusUnauthorized, post(h, strings.Replace(payload, "Delivered", "Failed", 1), signature))
+	})
+
+	t.Run("Failed payload can be redelivered", func(t *testing.T) {
+		assert.Equal(t, http.StatusInternalServerError, post(h, payload, signature))
+		assert.Equal(t, http.StatusOK, post(h, payload, strings.ToUpper(signature)))
+		assert.Equal(t, 1, handled)
+	})
+
+	t.Run("Replayed payload is rejected", func(t *testing.T) {
+		assert.Equal(t, http.StatusConflict, post(h, payload, signature))
+		assert.Equal(t, 1, handled)
+	})
+
+	t.Run("Only posts are accepted", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/termii/events", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+
+	t.Run("Errors are reported", func(t *testing.T) {
+		assert.Len(t, errs, 6)
+		assert.True(t, errors.Is(errs[0], webhook.ErrInvalidSignature))
+		assert.True(t, errors.Is(errs[4], webhook.ErrReplayed))
+	})
+
+	big, _ := webhook.NewHandler(secretKey, webhook.WithMaxBodySize(16))
+	t.Run("Oversized payload is rejected", func(t *testing.T) {
+		assert.Equal(t, http.StatusRequestEntityTooLarge, post(big, payload, signature))
+	})
+
+	t.Run("Empty secret key is refused", func(t *testing.T) {
+		_, err := webhook.NewHandler(" ")
+		assert.True(t, errors.Is(err, webhook.ErrNoSecretKey))
+		assert.False(t, webhook.Verify("", []byte(payload), webhook.Sign("", []byte(payload))))
+	})
+}